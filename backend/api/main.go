@@ -1,10 +1,13 @@
 package main
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -16,6 +19,13 @@ import (
 	"empresa-app/backend/internal/middleware"
 	"empresa-app/backend/internal/repository"
 	"empresa-app/backend/internal/service"
+	appconfig "empresa-app/backend/pkg/config"
+	"empresa-app/backend/pkg/envelope"
+	"empresa-app/backend/pkg/jwtkeys"
+	"empresa-app/backend/pkg/mailer"
+	"empresa-app/backend/pkg/oidc"
+	"empresa-app/backend/pkg/passhash"
+	"empresa-app/backend/pkg/storage"
 )
 
 func main() {
@@ -24,6 +34,9 @@ func main() {
 		log.Println("Arquivo .env não encontrado, usando variáveis do ambiente")
 	}
 
+	// Carregar configurações da aplicação
+	cfg := appconfig.Load()
+
 	// Inicializar conexão com banco de dados
 	db, err := setupDatabase()
 	if err != nil {
@@ -34,17 +47,101 @@ func main() {
 	// Inicializar router
 	router := setupRouter()
 
+	// Configurar hash de senhas
+	hasher, err := setupHasher(cfg.Auth)
+	if err != nil {
+		log.Fatalf("Erro ao configurar hash de senhas: %v", err)
+	}
+
 	// Inicializar repositórios
-	repos := initRepositories(db)
+	repos := initRepositories(db, hasher)
+
+	// Carregar chaves de assinatura dos tokens
+	keys, err := setupKeys(cfg)
+	if err != nil {
+		log.Fatalf("Erro ao carregar chaves JWT: %v", err)
+	}
+
+	// Carregar chaves mestras dos dados cifrados
+	chaveiro, err := setupChaveiro(cfg)
+	if err != nil {
+		log.Fatalf("Erro ao carregar chaves mestras: %v", err)
+	}
 
 	// Inicializar serviços
-	services := initServices(repos)
+	services := initServices(repos, cfg, keys, hasher, chaveiro)
+
+	// Carregar lista de revogação de tokens e agendar limpeza
+	if err := services.Revogacao.Load(); err != nil {
+		log.Fatalf("Erro ao carregar tokens revogados: %v", err)
+	}
+	services.Revogacao.StartCleanup(time.Minute)
+
+	// Carregar permissões dos cargos
+	if err := services.Permissao.Load(); err != nil {
+		log.Fatalf("Erro ao carregar permissões: %v", err)
+	}
+
+	// Carregar lista de senhas vazadas da política de senhas
+	if err := services.PoliticaSenha.Load(); err != nil {
+		log.Fatalf("Erro ao carregar lista de senhas vazadas: %v", err)
+	}
+
+	// Cifrar dados bancários antigos e reembrulhar os de chaves mestras
+	// anteriores em segundo plano
+	go func() {
+		regravados, err := services.DadosBancarios.Rotacionar()
+		if err != nil {
+			log.Printf("Erro ao rotacionar dados bancários: %v", err)
+			return
+		}
+		if regravados > 0 {
+			log.Printf("Dados bancários de %d colaboradores regravados com a chave mestra %s", regravados, chaveiro.Ativa())
+		}
+	}()
+
+	// Apagar os arquivos das exportações de dados cujo link expirou
+	go func() {
+		for {
+			if apagadas, err := services.ExportacaoDados.LimparExpiradas(); err != nil {
+				log.Printf("Erro ao limpar exportações de dados expiradas: %v", err)
+			} else if apagadas > 0 {
+				log.Printf("%d exportações de dados expiradas apagadas", apagadas)
+			}
+			time.Sleep(time.Hour)
+		}
+	}()
+
+	// Anonimizar os ex-colaboradores cujo prazo de retenção terminou (LGPD). Em
+	// simulação, apenas registra no log quantos seriam anonimizados.
+	if cfg.LGPD.AnonimizacaoIntervalo > 0 {
+		go func() {
+			for {
+				relatorio, err := services.Anonimizacao.Executar(cfg.LGPD.AnonimizacaoSimular, nil)
+				switch {
+				case err != nil:
+					log.Printf("Erro na anonimização de ex-colaboradores: %v", err)
+				case relatorio.Simulacao && len(relatorio.Colaboradores) > 0:
+					log.Printf("Anonimização em simulação: %d ex-colaboradores desligados até %s seriam anonimizados (LGPD_ANONIMIZACAO_SIMULAR=false para executar)",
+						len(relatorio.Colaboradores), relatorio.DataLimite)
+				case relatorio.Anonimizados > 0 || relatorio.Falhas > 0:
+					log.Printf("Anonimização: %d ex-colaboradores anonimizados, %d falhas", relatorio.Anonimizados, relatorio.Falhas)
+				}
+				time.Sleep(time.Duration(cfg.LGPD.AnonimizacaoIntervalo) * time.Hour)
+			}
+		}()
+	}
 
 	// LINHA ADICIONADA: Configurar middleware com o serviço de autenticação
 	middleware.SetAuthService(services.Auth)
+	middleware.SetPermissaoService(services.Permissao)
+	middleware.SetContaServicoService(services.ContaServico)
+	middleware.SetSessaoService(services.Sessao)
+	middleware.SetStatusColaboradorService(services.Status)
+	middleware.SetPersonificacaoService(services.Personificacao)
 
 	// Inicializar handlers
-	handlers := initHandlers(services)
+	handlers := initHandlers(services, cfg)
 
 	// Configurar rotas
 	setupRoutes(router, handlers)
@@ -88,7 +185,7 @@ func setupRouter() *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 	}))
@@ -96,35 +193,235 @@ func setupRouter() *gin.Engine {
 	return router
 }
 
-func initRepositories(db *sql.DB) *repository.Repositories {
+func initRepositories(db *sql.DB, hasher *passhash.Hasher) *repository.Repositories {
 	return &repository.Repositories{
-		Colaborador: repository.NewColaboradorRepository(db),
-		Documento:   repository.NewDocumentoRepository(db),
-		Ponto:       repository.NewPontoRepository(db),
+		Colaborador:             repository.NewColaboradorRepository(db, hasher),
+		Documento:               repository.NewDocumentoRepository(db),
+		Ponto:                   repository.NewPontoRepository(db),
+		RefreshToken:            repository.NewRefreshTokenRepository(db),
+		TokenRevogado:           repository.NewTokenRevogadoRepository(db),
+		Cargo:                   repository.NewCargoRepository(db),
+		Permissao:               repository.NewPermissaoRepository(db),
+		TokenRedefinicaoSenha:   repository.NewTokenRedefinicaoSenhaRepository(db),
+		DoisFatores:             repository.NewDoisFatoresRepository(db),
+		TentativaLogin:          repository.NewTentativaLoginRepository(db),
+		ContaServico:            repository.NewContaServicoRepository(db),
+		Sessao:                  repository.NewSessaoRepository(db),
+		HistoricoSenha:          repository.NewHistoricoSenhaRepository(db),
+		Personificacao:          repository.NewPersonificacaoRepository(db),
+		AutorizacaoOIDC:         repository.NewAutorizacaoOIDCRepository(db),
+		FotoPerfil:              repository.NewFotoPerfilRepository(db),
+		RevelacaoDadosBancarios: repository.NewRevelacaoDadosBancariosRepository(db),
+		Departamento:            repository.NewDepartamentoRepository(db),
+		CentroCusto:             repository.NewCentroCustoRepository(db),
+		Alocacao:                repository.NewAlocacaoRepository(db),
+		Desligamento:            repository.NewDesligamentoRepository(db),
+		ExportacaoDados:         repository.NewExportacaoDadosRepository(db),
+		Anonimizacao:            repository.NewAnonimizacaoRepository(db),
 	}
 }
 
-func initServices(repos *repository.Repositories) *service.Services {
+func initServices(
+	repos *repository.Repositories,
+	cfg *appconfig.Config,
+	keys *jwtkeys.KeySet,
+	hasher *passhash.Hasher,
+	chaveiro *envelope.Chaveiro,
+) *service.Services {
+	revogacao := service.NewRevogacaoService(repos.TokenRevogado)
+	sessoes := service.NewSessaoService(repos.Sessao, repos.RefreshToken, revogacao, cfg.Auth)
+	politica := service.NewPoliticaSenhaService(repos.HistoricoSenha, hasher, cfg.Auth)
+	doisFatores := service.NewDoisFatoresService(repos.DoisFatores, repos.Colaborador, repos.Cargo)
+	tentativa := service.NewTentativaLoginService(repos.TentativaLogin, repos.Colaborador, cfg.Auth)
+	auth := service.NewAuthService(repos.Colaborador, repos.RefreshToken, revogacao, sessoes, doisFatores, tentativa, keys, cfg.Auth)
+	permissao := service.NewPermissaoService(repos.Permissao, repos.Cargo)
+	m := setupMailer(cfg.Mail)
+	redefinicao := service.NewRedefinicaoSenhaService(
+		repos.Colaborador,
+		repos.TokenRedefinicaoSenha,
+		politica,
+		auth,
+		tentativa,
+		m,
+		cfg.Mail.AppURL,
+		time.Duration(cfg.Auth.ResetExpiration)*time.Minute,
+		time.Duration(cfg.Auth.ConviteExpiration)*time.Hour,
+	)
+	dadosBancarios := service.NewDadosBancariosService(repos.Colaborador, repos.RevelacaoDadosBancarios, chaveiro)
+	personificacao := service.NewPersonificacaoService(
+		repos.Personificacao,
+		repos.Colaborador,
+		auth,
+		permissao,
+		revogacao,
+		time.Duration(cfg.Auth.PersonificacaoDuracao)*time.Minute,
+	)
+	status := service.NewStatusColaboradorService(repos.Colaborador, sessoes, personificacao)
+
 	return &service.Services{
-		Auth:        service.NewAuthService(repos.Colaborador),
-		Colaborador: service.NewColaboradorService(repos.Colaborador),
-		Documento:   service.NewDocumentoService(repos.Documento),
-		Ponto:       service.NewPontoService(repos.Ponto),
+		Auth:           auth,
+		Colaborador:    service.NewColaboradorService(repos.Colaborador, repos.Cargo, politica, sessoes, redefinicao, dadosBancarios),
+		Documento:      service.NewDocumentoService(repos.Documento, repos.Colaborador, doisFatores),
+		Ponto:          service.NewPontoService(repos.Ponto),
+		Revogacao:      revogacao,
+		Permissao:      permissao,
+		Redefinicao:    redefinicao,
+		DoisFatores:    doisFatores,
+		Tentativa:      tentativa,
+		ContaServico:   service.NewContaServicoService(repos.ContaServico),
+		Sessao:         sessoes,
+		PoliticaSenha:  politica,
+		Status:         status,
+		Personificacao: personificacao,
+		Cargo:          service.NewCargoService(repos.Cargo, permissao),
+		FotoPerfil: service.NewFotoPerfilService(
+			repos.FotoPerfil,
+			storage.NewFileStorage(cfg.Storage.UploadDir),
+			cfg.Storage.MaxFileSize,
+		),
+		OIDC:           service.NewOIDCService(setupOIDC(cfg.Auth), repos.AutorizacaoOIDC, repos.Colaborador, auth, cfg.Auth),
+		DadosBancarios: dadosBancarios,
+		Importacao:     service.NewImportacaoService(repos.Colaborador, repos.Cargo, redefinicao, cfg.Storage.MaxFileSize),
+		Departamento:   service.NewDepartamentoService(repos.Departamento),
+		CentroCusto:    service.NewCentroCustoService(repos.CentroCusto),
+		Alocacao:       service.NewAlocacaoService(repos.Alocacao, repos.Colaborador, repos.Departamento, repos.CentroCusto),
+		Desligamento:   service.NewDesligamentoService(repos.Desligamento, repos.Colaborador, status),
+		ExportacaoDados: service.NewExportacaoDadosService(
+			repos.ExportacaoDados,
+			repos.Colaborador,
+			repos.Documento,
+			repos.Ponto,
+			repos.TentativaLogin,
+			repos.Alocacao,
+			repos.FotoPerfil,
+			dadosBancarios,
+			filepath.Join(cfg.Storage.UploadDir, "exportacoes"),
+			cfg.Auth.RefreshSecret,
+		),
+		Anonimizacao: service.NewAnonimizacaoService(repos.Anonimizacao, cfg.LGPD.RetencaoDias),
+	}
+}
+
+// setupKeys carrega as chaves assimétricas de JWT_KEYS_DIR. Sem diretório
+// configurado, usa HS256 com JWT_SECRET, o que só é permitido em desenvolvimento.
+func setupKeys(cfg *appconfig.Config) (*jwtkeys.KeySet, error) {
+	if cfg.IsProduction() && cfg.Auth.RefreshSecret == appconfig.DefaultRefreshSecret {
+		return nil, fmt.Errorf("REFRESH_SECRET deve ser configurado em produção")
+	}
+
+	if cfg.Auth.JWTKeysDir != "" {
+		return jwtkeys.LoadDir(cfg.Auth.JWTKeysDir, cfg.Auth.JWTActiveKeyID)
+	}
+
+	if cfg.IsProduction() {
+		return nil, fmt.Errorf("JWT_KEYS_DIR deve ser configurado em produção")
+	}
+
+	log.Println("JWT_KEYS_DIR não configurado, assinando tokens com HS256 (apenas desenvolvimento)")
+	return jwtkeys.NewHMACKeySet(cfg.Auth.JWTSecret), nil
+}
+
+// setupChaveiro carrega as chaves mestras de CHAVES_MESTRAS_ARQUIVO. Sem
+// arquivo configurado, deriva uma chave de JWT_SECRET, o que só é permitido
+// em desenvolvimento.
+func setupChaveiro(cfg *appconfig.Config) (*envelope.Chaveiro, error) {
+	if cfg.Cripto.ChavesArquivo != "" {
+		return envelope.Carregar(cfg.Cripto.ChavesArquivo, cfg.Cripto.ChaveAtiva)
+	}
+
+	if cfg.IsProduction() {
+		return nil, fmt.Errorf("CHAVES_MESTRAS_ARQUIVO deve ser configurado em produção")
 	}
+
+	log.Println("CHAVES_MESTRAS_ARQUIVO não configurado, usando chave derivada de JWT_SECRET (apenas desenvolvimento)")
+	chave := sha256.Sum256([]byte("chave-mestra:" + cfg.Auth.JWTSecret))
+	return envelope.NovoChaveiro("dev", chave[:])
 }
 
-func initHandlers(services *service.Services) *handler.Handlers {
+func setupHasher(cfg appconfig.AuthConfig) (*passhash.Hasher, error) {
+	return passhash.New(passhash.Params{
+		Algoritmo:     cfg.SenhaAlgoritmo,
+		Argon2Memory:  uint32(cfg.Argon2Memoria),
+		Argon2Time:    uint32(cfg.Argon2Iteracoes),
+		Argon2Threads: uint8(cfg.Argon2Paralelismo),
+		BcryptCost:    cfg.BcryptCusto,
+	})
+}
+
+// setupOIDC cria o cliente do provedor de identidade. Sem OIDC_ISSUER o
+// login único fica desativado.
+func setupOIDC(cfg appconfig.AuthConfig) *oidc.Client {
+	if cfg.OIDCIssuer == "" {
+		return nil
+	}
+
+	if cfg.OIDCAutoProvisionar && cfg.OIDCCargoPadrao <= 0 {
+		log.Fatal("OIDC_CARGO_PADRAO deve ser configurado quando OIDC_AUTO_PROVISIONAR está ativo")
+	}
+
+	return oidc.New(oidc.Config{
+		Issuer:       cfg.OIDCIssuer,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+	})
+}
+
+func setupMailer(cfg appconfig.MailConfig) mailer.Mailer {
+	if cfg.SMTPHost == "" {
+		log.Println("SMTP_HOST não configurado, emails serão apenas registrados no log")
+		return mailer.NewLogMailer()
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.From,
+	})
+}
+
+func initHandlers(services *service.Services, cfg *appconfig.Config) *handler.Handlers {
 	return &handler.Handlers{
-		Auth:        handler.NewAuthHandler(services.Auth),
-		Colaborador: handler.NewColaboradorHandler(services.Colaborador),
-		Documento:   handler.NewDocumentoHandler(services.Documento),
-		Ponto:       handler.NewPontoHandler(services.Ponto),
+		Auth:            handler.NewAuthHandler(services.Auth),
+		Colaborador:     handler.NewColaboradorHandler(services.Colaborador, services.Status, services.Desligamento),
+		Documento:       handler.NewDocumentoHandler(services.Documento),
+		Ponto:           handler.NewPontoHandler(services.Ponto),
+		Permissao:       handler.NewPermissaoHandler(services.Permissao),
+		Redefinicao:     handler.NewRedefinicaoSenhaHandler(services.Redefinicao),
+		DoisFatores:     handler.NewDoisFatoresHandler(services.DoisFatores),
+		Tentativa:       handler.NewTentativaLoginHandler(services.Tentativa),
+		ContaServico:    handler.NewContaServicoHandler(services.ContaServico),
+		Sessao:          handler.NewSessaoHandler(services.Sessao),
+		Personificacao:  handler.NewPersonificacaoHandler(services.Personificacao),
+		OIDC:            handler.NewOIDCHandler(services.OIDC, cfg.IsProduction()),
+		Cargo:           handler.NewCargoHandler(services.Cargo),
+		FotoPerfil:      handler.NewFotoPerfilHandler(services.FotoPerfil),
+		DadosBancarios:  handler.NewDadosBancariosHandler(services.DadosBancarios),
+		Importacao:      handler.NewImportacaoHandler(services.Importacao),
+		Departamento:    handler.NewDepartamentoHandler(services.Departamento),
+		CentroCusto:     handler.NewCentroCustoHandler(services.CentroCusto),
+		Alocacao:        handler.NewAlocacaoHandler(services.Alocacao),
+		Desligamento:    handler.NewDesligamentoHandler(services.Desligamento),
+		ExportacaoDados: handler.NewExportacaoDadosHandler(services.ExportacaoDados),
+		Anonimizacao:    handler.NewAnonimizacaoHandler(services.Anonimizacao),
 	}
 }
 
 func setupRoutes(router *gin.Engine, handlers *handler.Handlers) {
 	// Rotas públicas
+	router.GET("/.well-known/jwks.json", handlers.Auth.JWKS)
 	router.POST("/api/auth/login", handlers.Auth.Login)
+	router.POST("/api/auth/refresh", handlers.Auth.Refresh)
+	router.POST("/api/auth/forgot", handlers.Redefinicao.Solicitar)
+	router.POST("/api/auth/reset", handlers.Redefinicao.Redefinir)
+	router.POST("/api/auth/2fa/verificar", handlers.Auth.VerificarDoisFatores)
+	router.POST("/api/auth/2fa/cadastro", handlers.Auth.CadastrarDoisFatores)
+	router.POST("/api/auth/2fa/ativar", handlers.Auth.AtivarDoisFatores)
+	router.GET("/api/auth/oidc/login", handlers.OIDC.Iniciar)
+	router.GET("/api/auth/oidc/callback", handlers.OIDC.Callback)
+	router.GET("/api/exportacoes/:id/arquivo", handlers.ExportacaoDados.Baixar)
 
 	// Grupo de rotas protegidas
 	api := router.Group("/api")
@@ -132,19 +429,160 @@ func setupRoutes(router *gin.Engine, handlers *handler.Handlers) {
 	{
 		// Rotas de colaborador
 		api.GET("/me", handlers.Colaborador.GetMe)
-		api.PUT("/me", handlers.Colaborador.UpdateMe)
+		api.GET("/me/logins", handlers.Tentativa.ListarHistorico)
+		api.GET("/me/sessoes", handlers.Sessao.Listar)
+		api.GET("/equipe", handlers.Colaborador.Equipe)
+		api.GET("/fotos/:id", handlers.FotoPerfil.Obter)
+
+		// Ações sensíveis, bloqueadas durante a personificação
+		sensiveis := api.Group("")
+		sensiveis.Use(middleware.BloquearPersonificacao())
+		{
+			sensiveis.PUT("/me", handlers.Colaborador.UpdateMe)
+			sensiveis.GET("/me/dados-bancarios", handlers.DadosBancarios.RevelarMeus)
+			sensiveis.PUT("/me/dados-bancarios", handlers.DadosBancarios.AtualizarMeus)
+			sensiveis.PUT("/me/senha", handlers.Colaborador.AlterarSenha)
+			sensiveis.POST("/me/foto", handlers.FotoPerfil.Enviar)
+			sensiveis.DELETE("/me/foto", handlers.FotoPerfil.Remover)
+			sensiveis.POST("/me/2fa", handlers.DoisFatores.Cadastrar)
+			sensiveis.POST("/me/2fa/ativar", handlers.DoisFatores.Ativar)
+			sensiveis.DELETE("/me/2fa", handlers.DoisFatores.Desativar)
+			sensiveis.DELETE("/me/sessoes/:id", handlers.Sessao.Encerrar)
+			sensiveis.GET("/me/export", handlers.ExportacaoDados.Solicitar)
+			sensiveis.POST("/auth/logout", handlers.Auth.Logout)
+		}
 
 		// Rotas de documentos
 		api.POST("/documentos", handlers.Documento.Create)
-		api.GET("/documentos", handlers.Documento.List)
-		api.GET("/documentos/:id", handlers.Documento.GetByID)
-		api.PUT("/documentos/:id/aprovar", handlers.Documento.Aprovar)
-		api.PUT("/documentos/:id/rejeitar", handlers.Documento.Rejeitar)
-		api.PUT("/documentos/:id/enviar", handlers.Documento.Enviar)
-		api.GET("/documentos/:id/arquivo", handlers.Documento.Download)
+		api.GET("/documentos/aprovacoes", handlers.Documento.ListPendentesAprovacao)
+		// Aprovação: gestor direto de quem enviou ou, sem gestor ativo, o RH
+		api.PUT("/documentos/:id/aprovar", middleware.BloquearPersonificacao(), handlers.Documento.Aprovar)
+		api.PUT("/documentos/:id/rejeitar", middleware.BloquearPersonificacao(), handlers.Documento.Rejeitar)
+		api.PUT("/documentos/:id/enviar", middleware.RequirePermission(service.PermDocumentosEnviar), handlers.Documento.Enviar)
+
+		// Cadastro de colaboradores (RH)
+		colaboradores := api.Group("/colaboradores")
+		colaboradores.Use(middleware.RequirePermission(service.PermColaboradoresGerenciar))
+		{
+			colaboradores.GET("", handlers.Colaborador.List)
+			colaboradores.POST("", handlers.Colaborador.Create)
+			colaboradores.POST("/importacao", handlers.Importacao.Importar)
+			colaboradores.GET("/:id", handlers.Colaborador.GetByID)
+			colaboradores.PUT("/:id", handlers.Colaborador.Update)
+			colaboradores.DELETE("/:id", handlers.Colaborador.Desativar)
+			colaboradores.POST("/:id/convite", handlers.Colaborador.ReenviarConvite)
+			colaboradores.PUT("/:id/gestor", handlers.Colaborador.DefinirGestor)
+			colaboradores.GET("/:id/historico", handlers.Colaborador.Historico)
+			colaboradores.GET("/:id/alocacoes", handlers.Alocacao.List)
+			colaboradores.POST("/:id/alocacoes", handlers.Alocacao.Alocar)
+			colaboradores.DELETE("/:id/alocacoes/:alocacaoId", handlers.Alocacao.Remover)
+		}
+
+		// Cargos: consulta liberada para formulários de cadastro
+		api.GET("/cargos", handlers.Cargo.List)
+		api.GET("/cargos/:id", handlers.Cargo.GetByID)
+
+		// Departamentos e centros de custo: consulta liberada para filtros e formulários
+		api.GET("/departamentos", handlers.Departamento.List)
+		api.GET("/departamentos/:id", handlers.Departamento.GetByID)
+		api.GET("/centros-custo", handlers.CentroCusto.List)
+		api.GET("/centros-custo/:id", handlers.CentroCusto.GetByID)
 
 		// Rotas de ponto
 		api.POST("/pontos", handlers.Ponto.Registrar)
 		api.GET("/pontos", handlers.Ponto.Listar)
 	}
+
+	// Consulta de documentos: também aceita chaves de API com o escopo documentos:read
+	documentos := router.Group("/api/documentos")
+	documentos.Use(middleware.AuthMiddlewareComEscopo(service.EscopoDocumentosRead))
+	{
+		documentos.GET("", handlers.Documento.List)
+		documentos.GET("/:id", handlers.Documento.GetByID)
+		documentos.GET("/:id/arquivo", handlers.Documento.Download)
+	}
+
+	// Grupo de rotas administrativas
+	admin := router.Group("/api/admin")
+	admin.Use(middleware.AuthMiddleware())
+	{
+		admin.POST("/colaboradores/:id/revogar-tokens", middleware.RequirePermission(service.PermSessoesRevogar), handlers.Auth.RevogarTokensColaborador)
+		admin.GET("/colaboradores/:id/sessoes", middleware.RequirePermission(service.PermSessoesRevogar), handlers.Sessao.ListarColaborador)
+		admin.DELETE("/colaboradores/:id/sessoes", middleware.RequirePermission(service.PermSessoesRevogar), handlers.Sessao.EncerrarTodas)
+		admin.PUT("/colaboradores/:id/status", middleware.RequirePermission(service.PermColaboradoresGerenciar), handlers.Colaborador.AlterarStatus)
+		admin.POST("/colaboradores/:id/desligamento", middleware.RequirePermission(service.PermColaboradoresGerenciar), handlers.Desligamento.Desligar)
+		admin.GET("/colaboradores/:id/desligamentos", middleware.RequirePermission(service.PermColaboradoresGerenciar), handlers.Desligamento.List)
+		admin.POST("/colaboradores/:id/desbloquear", middleware.RequirePermission(service.PermContasDesbloquear), handlers.Tentativa.Desbloquear)
+
+		// Anonimização de ex-colaboradores (LGPD)
+		anonimizacao := admin.Group("")
+		anonimizacao.Use(middleware.RequirePermission(service.PermColaboradoresAnonimizar))
+		{
+			anonimizacao.GET("/anonimizacoes", handlers.Anonimizacao.List)
+			anonimizacao.GET("/anonimizacoes/previa", handlers.Anonimizacao.Previa)
+			anonimizacao.POST("/anonimizacoes", handlers.Anonimizacao.Executar)
+		}
+
+		// Personificação para suporte
+		personificacao := admin.Group("")
+		personificacao.Use(middleware.RequirePermission(service.PermColaboradoresPersonificar))
+		{
+			personificacao.POST("/colaboradores/:id/personificar", handlers.Personificacao.Iniciar)
+			personificacao.GET("/personificacoes", handlers.Personificacao.List)
+			personificacao.GET("/personificacoes/:id/requisicoes", handlers.Personificacao.ListRequisicoes)
+			personificacao.DELETE("/personificacoes/:id", handlers.Personificacao.Encerrar)
+		}
+
+		// Dados bancários completos (financeiro); toda consulta é registrada
+		dadosBancarios := admin.Group("")
+		dadosBancarios.Use(middleware.RequirePermission(service.PermDadosBancariosRevelar))
+		{
+			dadosBancarios.POST("/colaboradores/:id/dados-bancarios/revelar", handlers.DadosBancarios.Revelar)
+			dadosBancarios.GET("/colaboradores/:id/dados-bancarios/revelacoes", handlers.DadosBancarios.ListRevelacoes)
+		}
+
+		// Gestão de permissões dos cargos
+		permissoes := admin.Group("")
+		permissoes.Use(middleware.RequirePermission(service.PermPermissoesGerenciar))
+		{
+			permissoes.GET("/permissoes", handlers.Permissao.List)
+			permissoes.GET("/cargos/:id/permissoes", handlers.Permissao.GetCargo)
+			permissoes.POST("/cargos/:id/permissoes", handlers.Permissao.Grant)
+			permissoes.DELETE("/cargos/:id/permissoes/:permissao", handlers.Permissao.Revoke)
+		}
+
+		// Cadastro de cargos
+		cargos := admin.Group("")
+		cargos.Use(middleware.RequirePermission(service.PermCargosGerenciar))
+		{
+			cargos.POST("/cargos", handlers.Cargo.Create)
+			cargos.PUT("/cargos/:id", handlers.Cargo.Update)
+			cargos.DELETE("/cargos/:id", handlers.Cargo.Delete)
+			cargos.GET("/cargos/:id/historico", handlers.Cargo.Historico)
+		}
+
+		// Cadastro de departamentos e centros de custo
+		departamentos := admin.Group("")
+		departamentos.Use(middleware.RequirePermission(service.PermDepartamentosGerenciar))
+		{
+			departamentos.POST("/departamentos", handlers.Departamento.Create)
+			departamentos.PUT("/departamentos/:id", handlers.Departamento.Update)
+			departamentos.DELETE("/departamentos/:id", handlers.Departamento.Delete)
+			departamentos.POST("/centros-custo", handlers.CentroCusto.Create)
+			departamentos.PUT("/centros-custo/:id", handlers.CentroCusto.Update)
+			departamentos.DELETE("/centros-custo/:id", handlers.CentroCusto.Delete)
+		}
+
+		// Contas de serviço e chaves de API
+		contasServico := admin.Group("")
+		contasServico.Use(middleware.RequirePermission(service.PermContasServicoGerenciar))
+		{
+			contasServico.POST("/contas-servico", handlers.ContaServico.Create)
+			contasServico.GET("/contas-servico", handlers.ContaServico.List)
+			contasServico.DELETE("/contas-servico/:id", handlers.ContaServico.Desativar)
+			contasServico.POST("/contas-servico/:id/chaves", handlers.ContaServico.CriarChave)
+			contasServico.GET("/contas-servico/:id/chaves", handlers.ContaServico.ListChaves)
+			contasServico.DELETE("/chaves-api/:id", handlers.ContaServico.RevogarChave)
+		}
+	}
 }
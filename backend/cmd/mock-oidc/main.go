@@ -0,0 +1,258 @@
+// Provedor OpenID Connect de teste para desenvolvimento. Emite ID tokens para
+// qualquer email informado no formulário de login, sem senha.
+//
+//	go run ./cmd/mock-oidc -addr :9000
+//
+// e configure a API com OIDC_ISSUER=http://localhost:9000 e
+// OIDC_CLIENT_ID=empresa-app. Não use fora do ambiente de desenvolvimento.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const kid = "mock-oidc"
+
+type autorizacao struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	email         string
+	nome          string
+	expiraEm      time.Time
+}
+
+type provedor struct {
+	issuer   string
+	clientID string
+	key      *rsa.PrivateKey
+
+	mu      sync.Mutex
+	codigos map[string]*autorizacao
+}
+
+var formulario = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="pt-BR">
+<head><meta charset="utf-8"><title>Provedor OIDC de teste</title></head>
+<body>
+<h1>Provedor OIDC de teste</h1>
+<form method="post" action="/authorize">
+{{range $nome, $valor := .Params}}<input type="hidden" name="{{$nome}}" value="{{$valor}}">
+{{end}}<p><label>Email <input name="email" type="email" required autofocus></label></p>
+<p><label>Nome <input name="name"></label></p>
+<p><button type="submit">Entrar</button></p>
+</form>
+</body>
+</html>
+`))
+
+func main() {
+	addr := flag.String("addr", ":9000", "endereço HTTP")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer publicado na descoberta")
+	clientID := flag.String("client-id", "empresa-app", "client_id aceito")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Erro ao gerar chave: %v", err)
+	}
+
+	p := &provedor{
+		issuer:   strings.TrimRight(*issuer, "/"),
+		clientID: *clientID,
+		key:      key,
+		codigos:  map[string]*autorizacao{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+
+	log.Printf("Provedor OIDC de teste em %s (issuer %s, client_id %s)", *addr, p.issuer, p.clientID)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func (p *provedor) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *provedor) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// authorize exibe o formulário (GET) e, ao enviar o email (POST), redireciona
+// de volta ao cliente com o código
+func (p *provedor) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "requisição inválida", http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]string{}
+	for _, nome := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+		params[nome] = r.Form.Get(nome)
+	}
+
+	if params["response_type"] != "code" || params["client_id"] != p.clientID || params["redirect_uri"] == "" {
+		http.Error(w, "response_type, client_id ou redirect_uri inválidos", http.StatusBadRequest)
+		return
+	}
+	if params["code_challenge"] == "" || params["code_challenge_method"] != "S256" {
+		http.Error(w, "PKCE com S256 é obrigatório", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		formulario.Execute(w, map[string]interface{}{"Params": params})
+		return
+	}
+
+	email := strings.TrimSpace(r.Form.Get("email"))
+	if email == "" {
+		http.Error(w, "email é obrigatório", http.StatusBadRequest)
+		return
+	}
+
+	codigo := randomString()
+	p.mu.Lock()
+	p.codigos[codigo] = &autorizacao{
+		clientID:      params["client_id"],
+		redirectURI:   params["redirect_uri"],
+		codeChallenge: params["code_challenge"],
+		nonce:         params["nonce"],
+		email:         email,
+		nome:          r.Form.Get("name"),
+		expiraEm:      time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(params["redirect_uri"])
+	if err != nil {
+		http.Error(w, "redirect_uri inválido", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", codigo)
+	query.Set("state", params["state"])
+	redirect.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *provedor) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		tokenError(w, "invalid_request")
+		return
+	}
+
+	clientID := r.Form.Get("client_id")
+	if id, _, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(id)
+	}
+
+	codigo := r.Form.Get("code")
+	p.mu.Lock()
+	a := p.codigos[codigo]
+	delete(p.codigos, codigo)
+	p.mu.Unlock()
+
+	if r.Form.Get("grant_type") != "authorization_code" || a == nil || time.Now().After(a.expiraEm) {
+		tokenError(w, "invalid_grant")
+		return
+	}
+	if clientID != a.clientID || r.Form.Get("redirect_uri") != a.redirectURI {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != a.codeChallenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	sub := sha256.Sum256([]byte(strings.ToLower(a.email)))
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            base64.RawURLEncoding.EncodeToString(sub[:16]),
+		"aud":            a.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          a.nonce,
+		"email":          a.email,
+		"email_verified": true,
+		"name":           a.nome,
+	})
+	idToken.Header["kid"] = kid
+
+	assinado, err := idToken.SignedString(p.key)
+	if err != nil {
+		tokenError(w, "server_error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     assinado,
+	})
+}
+
+func tokenError(w http.ResponseWriter, codigo string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": codigo})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("Erro ao gerar valor aleatório: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
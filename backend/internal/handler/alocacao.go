@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type AlocacaoHandler struct {
+	alocacaoService *service.AlocacaoService
+}
+
+func NewAlocacaoHandler(alocacaoService *service.AlocacaoService) *AlocacaoHandler {
+	return &AlocacaoHandler{alocacaoService: alocacaoService}
+}
+
+// List - Histórico de alocações do colaborador (RH)
+func (h *AlocacaoHandler) List(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	alocacoes, err := h.alocacaoService.List(id)
+	if err != nil {
+		respondAlocacaoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, alocacoes)
+}
+
+// Alocar - Alocar o colaborador em departamento e/ou centro de custo a partir
+// de uma data (RH)
+func (h *AlocacaoHandler) Alocar(c *gin.Context) {
+	usuarioID, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.AlocacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	alocacao, err := h.alocacaoService.Alocar(id, req, usuarioID)
+	if err != nil {
+		respondAlocacaoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, alocacao)
+}
+
+// Remover - Excluir alocação registrada por engano (RH)
+func (h *AlocacaoHandler) Remover(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	alocacaoID, err := strconv.Atoi(c.Param("alocacaoId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID de alocação inválido"})
+		return
+	}
+
+	if err := h.alocacaoService.Remover(id, alocacaoID); err != nil {
+		respondAlocacaoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alocação removida com sucesso"})
+}
+
+// parseFiltroAlocacao lê os filtros departamento_id e centro_custo_id das
+// listagens; ausentes, retornam zero
+func parseFiltroAlocacao(c *gin.Context) (departamentoID, centroCustoID int, err error) {
+	if valor := c.Query("departamento_id"); valor != "" {
+		if departamentoID, err = strconv.Atoi(valor); err != nil {
+			return 0, 0, errors.New("ID de departamento inválido")
+		}
+	}
+	if valor := c.Query("centro_custo_id"); valor != "" {
+		if centroCustoID, err = strconv.Atoi(valor); err != nil {
+			return 0, 0, errors.New("ID de centro de custo inválido")
+		}
+	}
+	return departamentoID, centroCustoID, nil
+}
+
+func respondAlocacaoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrColaboradorNaoEncontrado), errors.Is(err, service.ErrAlocacaoNaoEncontrada):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDepartamentoNaoEncontrado), errors.Is(err, service.ErrCentroCustoNaoEncontrado),
+		errors.Is(err, service.ErrDepartamentoInativo), errors.Is(err, service.ErrCentroCustoInativo),
+		errors.Is(err, service.ErrAlocacaoVazia), errors.Is(err, service.ErrAlocacaoAntesAdmissao),
+		errors.Is(err, service.ErrVigenciaInvalida):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar alocação: " + err.Error()})
+	}
+}
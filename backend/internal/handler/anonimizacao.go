@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+)
+
+type AnonimizacaoHandler struct {
+	anonimizacaoService *service.AnonimizacaoService
+}
+
+func NewAnonimizacaoHandler(anonimizacaoService *service.AnonimizacaoService) *AnonimizacaoHandler {
+	return &AnonimizacaoHandler{anonimizacaoService: anonimizacaoService}
+}
+
+// Previa - Relatório de quem seria anonimizado agora, sem alterar nada
+func (h *AnonimizacaoHandler) Previa(c *gin.Context) {
+	relatorio, err := h.anonimizacaoService.Executar(true, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar relatório: " + err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, relatorio)
+}
+
+// Executar - Anonimizar agora os ex-colaboradores cujo prazo de retenção
+// terminou, sem esperar a rotina agendada
+func (h *AnonimizacaoHandler) Executar(c *gin.Context) {
+	realizadoPor, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	relatorio, err := h.anonimizacaoService.Executar(false, &realizadoPor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao anonimizar: " + err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, relatorio)
+}
+
+// List - Registro das anonimizações realizadas
+func (h *AnonimizacaoHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	anonimizacoes, err := h.anonimizacaoService.List(limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar anonimizações: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, anonimizacoes)
+}
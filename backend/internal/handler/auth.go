@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"empresa-app/backend/internal/middleware"
 	"empresa-app/backend/internal/model"
 	"empresa-app/backend/internal/service"
 )
@@ -25,11 +29,152 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response, err := h.authService.Login(req.Email, req.Senha)
+	response, err := h.authService.Login(req.Email, req.Senha, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondLoginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Refresh - Renovar o token de acesso usando um refresh token
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.authService.Refresh(req.RefreshToken, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, service.ErrRefreshTokenInvalido) ||
+			errors.Is(err, service.ErrRefreshTokenExpirado) ||
+			errors.Is(err, service.ErrRefreshTokenReusado) ||
+			errors.Is(err, service.ErrColaboradorSemAcesso) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao renovar token: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Logout - Revogar o token atual (ou todas as sessões do colaborador)
+func (h *AuthHandler) Logout(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
 
+	jti, expiraEm, err := middleware.CurrentToken(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Corpo opcional
+	var req model.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+			return
+		}
+	}
+
+	if err := h.authService.Logout(colaboradorID, middleware.CurrentSession(c), jti, expiraEm, req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fazer logout: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logout realizado com sucesso"})
+}
+
+// RevogarTokensColaborador - Revogar todos os tokens de um colaborador (admin)
+func (h *AuthHandler) RevogarTokensColaborador(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.authService.RevogarTodos(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao revogar tokens: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tokens do colaborador revogados com sucesso"})
+}
+
+// VerificarDoisFatores - Concluir login com código TOTP ou de recuperação
+func (h *AuthHandler) VerificarDoisFatores(c *gin.Context) {
+	var req model.DoisFatoresVerificarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.authService.VerificarDoisFatores(req.Desafio, req.Codigo, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondLoginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CadastrarDoisFatores - Iniciar cadastro obrigatório do 2FA durante o login
+func (h *AuthHandler) CadastrarDoisFatores(c *gin.Context) {
+	var req model.DesafioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.authService.IniciarCadastroDoisFatores(req.Desafio)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// AtivarDoisFatores - Confirmar cadastro obrigatório do 2FA e concluir o login
+func (h *AuthHandler) AtivarDoisFatores(c *gin.Context) {
+	var req model.DoisFatoresVerificarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	response, err := h.authService.AtivarDoisFatores(req.Desafio, req.Codigo, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondLoginError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, response)
 }
+
+// respondLoginError responde 429 com Retry-After quando a conta ou o IP estão
+// em espera, e 401 nos demais casos
+func respondLoginError(c *gin.Context, err error) {
+	var bloqueado *service.LoginBloqueadoError
+	if errors.As(err, &bloqueado) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(bloqueado.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+}
+
+// JWKS - Publicar as chaves públicas de verificação dos tokens
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
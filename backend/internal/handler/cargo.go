@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type CargoHandler struct {
+	cargoService *service.CargoService
+}
+
+func NewCargoHandler(cargoService *service.CargoService) *CargoHandler {
+	return &CargoHandler{cargoService: cargoService}
+}
+
+// List - Listar cargos
+func (h *CargoHandler) List(c *gin.Context) {
+	cargos, err := h.cargoService.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar cargos: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cargos)
+}
+
+// GetByID - Obter cargo com a quantidade de colaboradores
+func (h *CargoHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	cargo, err := h.cargoService.GetByID(id)
+	if err != nil {
+		respondCargoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cargo)
+}
+
+// Create - Cadastrar cargo (admin)
+func (h *CargoHandler) Create(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	var req model.CargoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	cargo, err := h.cargoService.Create(req, colaboradorID)
+	if err != nil {
+		respondCargoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cargo)
+}
+
+// Update - Editar cargo (admin)
+func (h *CargoHandler) Update(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.CargoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	cargo, err := h.cargoService.Update(id, req, colaboradorID)
+	if err != nil {
+		respondCargoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cargo)
+}
+
+// Delete - Excluir cargo sem colaboradores (admin)
+func (h *CargoHandler) Delete(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.cargoService.Delete(id, colaboradorID); err != nil {
+		respondCargoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Cargo excluído com sucesso"})
+}
+
+// Historico - Listar alterações do cargo (admin)
+func (h *CargoHandler) Historico(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	historico, err := h.cargoService.Historico(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar histórico: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
+
+func respondCargoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCargoNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCargoNomeEmUso), errors.Is(err, service.ErrCargoComColaboradores):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNomeObrigatorio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar cargo: " + err.Error()})
+	}
+}
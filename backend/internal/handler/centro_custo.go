@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type CentroCustoHandler struct {
+	centroCustoService *service.CentroCustoService
+}
+
+func NewCentroCustoHandler(centroCustoService *service.CentroCustoService) *CentroCustoHandler {
+	return &CentroCustoHandler{centroCustoService: centroCustoService}
+}
+
+// List - Listar centros de custo (inativos apenas com ?inativos=true)
+func (h *CentroCustoHandler) List(c *gin.Context) {
+	centros, err := h.centroCustoService.List(c.Query("inativos") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar centros de custo: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, centros)
+}
+
+// GetByID - Obter centro de custo
+func (h *CentroCustoHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	centro, err := h.centroCustoService.GetByID(id)
+	if err != nil {
+		respondCentroCustoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, centro)
+}
+
+// Create - Cadastrar centro de custo (admin)
+func (h *CentroCustoHandler) Create(c *gin.Context) {
+	var req model.CentroCustoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	centro, err := h.centroCustoService.Create(req)
+	if err != nil {
+		respondCentroCustoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, centro)
+}
+
+// Update - Editar ou desativar centro de custo (admin)
+func (h *CentroCustoHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.CentroCustoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	centro, err := h.centroCustoService.Update(id, req)
+	if err != nil {
+		respondCentroCustoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, centro)
+}
+
+// Delete - Excluir centro de custo que nunca teve colaboradores (admin)
+func (h *CentroCustoHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.centroCustoService.Delete(id); err != nil {
+		respondCentroCustoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Centro de custo excluído com sucesso"})
+}
+
+func respondCentroCustoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrCentroCustoNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCentroCustoCodigoEmUso), errors.Is(err, service.ErrCentroCustoEmUso):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNomeObrigatorio), errors.Is(err, service.ErrCodigoObrigatorio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar centro de custo: " + err.Error()})
+	}
+}
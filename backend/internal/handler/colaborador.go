@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -11,11 +13,21 @@ import (
 )
 
 type ColaboradorHandler struct {
-	colaboradorService *service.ColaboradorService
+	colaboradorService  *service.ColaboradorService
+	statusService       *service.StatusColaboradorService
+	desligamentoService *service.DesligamentoService
 }
 
-func NewColaboradorHandler(colaboradorService *service.ColaboradorService) *ColaboradorHandler {
-	return &ColaboradorHandler{colaboradorService: colaboradorService}
+func NewColaboradorHandler(
+	colaboradorService *service.ColaboradorService,
+	statusService *service.StatusColaboradorService,
+	desligamentoService *service.DesligamentoService,
+) *ColaboradorHandler {
+	return &ColaboradorHandler{
+		colaboradorService:  colaboradorService,
+		statusService:       statusService,
+		desligamentoService: desligamentoService,
+	}
 }
 
 // GetMe - Obter dados do usuário logado
@@ -52,7 +64,18 @@ func (h *ColaboradorHandler) UpdateMe(c *gin.Context) {
 	// Assegurar que o ID é do usuário logado
 	req.ID = colaboradorID
 
-	if err := h.colaboradorService.Update(&req); err != nil {
+	// Status e cargo só podem ser alterados por administradores; a foto, pelo
+	// envio em /me/foto. Dados bancários são alterados em /me/dados-bancarios.
+	atual, err := h.colaboradorService.GetByID(colaboradorID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+		return
+	}
+	req.Status = atual.Status
+	req.CargoID = atual.CargoID
+	req.FotoPerfil = atual.FotoPerfil
+
+	if err := h.colaboradorService.Update(&req, colaboradorID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar: " + err.Error()})
 		return
 	}
@@ -66,3 +89,295 @@ func (h *ColaboradorHandler) UpdateMe(c *gin.Context) {
 
 	c.JSON(http.StatusOK, colaborador)
 }
+
+// AlterarSenha - Alterar a senha do usuário logado
+func (h *ColaboradorHandler) AlterarSenha(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req model.AlterarSenhaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	err = h.colaboradorService.AlterarSenha(colaboradorID, middleware.CurrentSession(c), req.SenhaAtual, req.NovaSenha)
+	if err != nil {
+		var politicaErr *service.PoliticaSenhaError
+		if errors.Is(err, service.ErrSenhaAtualIncorreta) || errors.As(err, &politicaErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao alterar senha: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha alterada com sucesso"})
+}
+
+// AlterarStatus - Alterar o status de um colaborador (admin)
+func (h *ColaboradorHandler) AlterarStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.StatusColaboradorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	alteradoPor, _ := middleware.CurrentUser(c)
+	if err := h.statusService.Alterar(id, req.Status, alteradoPor); err != nil {
+		if errors.Is(err, service.ErrStatusInvalido) || errors.Is(err, service.ErrDesligamentoPeloFluxo) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrColaboradorAnonimizado) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrColaboradorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao alterar status: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Status alterado com sucesso"})
+}
+
+// List - Buscar colaboradores com filtros e paginação (RH)
+func (h *ColaboradorHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	cargoID, _ := strconv.Atoi(c.Query("cargo_id"))
+	gestorID, _ := strconv.Atoi(c.Query("gestor_id"))
+
+	lista, err := h.colaboradorService.List(model.ColaboradorFiltro{
+		Busca:    c.Query("busca"),
+		CargoID:  cargoID,
+		GestorID: gestorID,
+		Status:   c.Query("status"),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrStatusInvalido) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar colaboradores: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lista)
+}
+
+// GetByID - Obter um colaborador (RH)
+func (h *ColaboradorHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	colaborador, err := h.colaboradorService.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+		return
+	}
+
+	c.JSON(http.StatusOK, colaborador)
+}
+
+// Create - Cadastrar colaborador e enviar o convite para definir a senha (RH)
+func (h *ColaboradorHandler) Create(c *gin.Context) {
+	var req model.ColaboradorAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	cadastradoPor, _ := middleware.CurrentUser(c)
+	colaborador, err := h.colaboradorService.Cadastrar(req, cadastradoPor)
+	if err != nil {
+		respondCadastroError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, colaborador)
+}
+
+// Update - Atualizar dados cadastrais de um colaborador (RH)
+func (h *ColaboradorHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.ColaboradorAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	alteradoPor, _ := middleware.CurrentUser(c)
+	colaborador, err := h.colaboradorService.AtualizarCadastro(id, req, alteradoPor)
+	if err != nil {
+		respondCadastroError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, colaborador)
+}
+
+// Desativar - Desligar um colaborador pelo fluxo de desligamento, com o mesmo
+// corpo de POST /api/admin/colaboradores/:id/desligamento (RH)
+func (h *ColaboradorHandler) Desativar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.DesligamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	atual, _ := middleware.CurrentUser(c)
+	desligamento, err := h.desligamentoService.Desligar(id, req, atual)
+	if err != nil {
+		respondDesligamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, desligamento)
+}
+
+// ReenviarConvite - Enviar novo link para o colaborador definir a senha (RH)
+func (h *ColaboradorHandler) ReenviarConvite(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.colaboradorService.ReenviarConvite(id); err != nil {
+		if errors.Is(err, service.ErrColaboradorNaoEncontrado) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao enviar convite: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Convite enviado"})
+}
+
+// Historico - Alterações do cadastro de um colaborador: quem alterou, quando
+// e os valores anteriores e novos (RH). ?campo=cargo filtra por campo.
+func (h *ColaboradorHandler) Historico(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	historico, err := h.colaboradorService.Historico(id, c.Query("campo"), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCampoHistoricoInvalido):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrColaboradorNaoEncontrado):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar histórico: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
+
+// DefinirGestor - Definir ou remover o gestor direto de um colaborador (RH)
+func (h *ColaboradorHandler) DefinirGestor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.GestorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	alteradoPor, _ := middleware.CurrentUser(c)
+	colaborador, err := h.colaboradorService.DefinirGestor(id, req.GestorID, alteradoPor)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrColaboradorNaoEncontrado):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+		case errors.Is(err, service.ErrGestorNaoEncontrado),
+			errors.Is(err, service.ErrGestorDesligado),
+			errors.Is(err, service.ErrGestorProprio):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrGestorCiclo):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao definir gestor: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, colaborador)
+}
+
+// Equipe - Listar os subordinados diretos do usuário logado
+func (h *ColaboradorHandler) Equipe(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	lista, err := h.colaboradorService.Equipe(colaboradorID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar equipe: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lista)
+}
+
+func respondCadastroError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrEmailEmUso):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNomeObrigatorio),
+		errors.Is(err, service.ErrCargoInvalido),
+		errors.Is(err, service.ErrDataAdmissao):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrColaboradorNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar colaborador: " + err.Error()})
+	}
+}
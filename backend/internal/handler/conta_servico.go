@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type ContaServicoHandler struct {
+	contaServicoService *service.ContaServicoService
+}
+
+func NewContaServicoHandler(contaServicoService *service.ContaServicoService) *ContaServicoHandler {
+	return &ContaServicoHandler{contaServicoService: contaServicoService}
+}
+
+// Create - Criar conta de serviço
+func (h *ContaServicoHandler) Create(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	var conta model.ContaServico
+	if err := c.ShouldBindJSON(&conta); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	conta.CriadoPor = &colaboradorID
+	if err := h.contaServicoService.Create(&conta); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar conta de serviço: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, conta)
+}
+
+// List - Listar contas de serviço
+func (h *ContaServicoHandler) List(c *gin.Context) {
+	contas, err := h.contaServicoService.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar contas de serviço: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, contas)
+}
+
+// Desativar - Desativar conta de serviço e revogar suas chaves
+func (h *ContaServicoHandler) Desativar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.contaServicoService.Desativar(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Conta de serviço desativada com sucesso"})
+}
+
+// CriarChave - Gerar chave de API para a conta de serviço
+func (h *ContaServicoHandler) CriarChave(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.ChaveAPICreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	var expiraEm *time.Time
+	if req.ExpiraEm != "" {
+		data, err := time.Parse("2006-01-02", req.ExpiraEm)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de data inválido"})
+			return
+		}
+		expiraEm = &data
+	}
+
+	resp, err := h.contaServicoService.CriarChave(id, req.Escopos, expiraEm, colaboradorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao criar chave de API: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListChaves - Listar chaves de API da conta de serviço
+func (h *ContaServicoHandler) ListChaves(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	chaves, err := h.contaServicoService.ListChaves(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar chaves de API: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, chaves)
+}
+
+// RevogarChave - Revogar chave de API
+func (h *ContaServicoHandler) RevogarChave(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.contaServicoService.RevogarChave(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Chave de API revogada com sucesso"})
+}
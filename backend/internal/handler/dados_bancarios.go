@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type DadosBancariosHandler struct {
+	dadosBancariosService *service.DadosBancariosService
+}
+
+func NewDadosBancariosHandler(dadosBancariosService *service.DadosBancariosService) *DadosBancariosHandler {
+	return &DadosBancariosHandler{dadosBancariosService: dadosBancariosService}
+}
+
+// AtualizarMeus - Cadastrar ou alterar os dados bancários do usuário logado
+func (h *DadosBancariosHandler) AtualizarMeus(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req model.DadosBancarios
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	dados, err := h.dadosBancariosService.Atualizar(colaboradorID, req)
+	if err != nil {
+		respondDadosBancariosError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dados_bancarios": dados})
+}
+
+// RevelarMeus - Ver os dados bancários completos do usuário logado
+func (h *DadosBancariosHandler) RevelarMeus(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	dados, err := h.dadosBancariosService.RevelarProprios(colaboradorID, c.ClientIP())
+	if err != nil {
+		respondDadosBancariosError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"dados_bancarios": dados})
+}
+
+// Revelar - Ver os dados bancários completos de um colaborador, informando o
+// motivo (financeiro)
+func (h *DadosBancariosHandler) Revelar(c *gin.Context) {
+	reveladoPor, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.RevelarDadosBancariosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Motivo é obrigatório"})
+		return
+	}
+
+	dados, err := h.dadosBancariosService.Revelar(id, reveladoPor, req.Motivo, c.ClientIP())
+	if err != nil {
+		respondDadosBancariosError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"dados_bancarios": dados})
+}
+
+// ListRevelacoes - Listar quem viu os dados bancários completos de um
+// colaborador (financeiro)
+func (h *DadosBancariosHandler) ListRevelacoes(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	revelacoes, err := h.dadosBancariosService.ListRevelacoes(id, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar consultas: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, revelacoes)
+}
+
+func respondDadosBancariosError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBancoInvalido),
+		errors.Is(err, service.ErrAgenciaInvalida),
+		errors.Is(err, service.ErrContaInvalida),
+		errors.Is(err, service.ErrChavePixInvalida),
+		errors.Is(err, service.ErrDadosBancariosIncompletos),
+		errors.Is(err, service.ErrMotivoObrigatorio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrColaboradorNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Colaborador não encontrado"})
+	case errors.Is(err, service.ErrDadosBancariosNaoCadastrados):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro nos dados bancários: " + err.Error()})
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type DepartamentoHandler struct {
+	departamentoService *service.DepartamentoService
+}
+
+func NewDepartamentoHandler(departamentoService *service.DepartamentoService) *DepartamentoHandler {
+	return &DepartamentoHandler{departamentoService: departamentoService}
+}
+
+// List - Listar departamentos (inativos apenas com ?inativos=true)
+func (h *DepartamentoHandler) List(c *gin.Context) {
+	departamentos, err := h.departamentoService.List(c.Query("inativos") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar departamentos: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, departamentos)
+}
+
+// GetByID - Obter departamento
+func (h *DepartamentoHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	departamento, err := h.departamentoService.GetByID(id)
+	if err != nil {
+		respondDepartamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, departamento)
+}
+
+// Create - Cadastrar departamento (admin)
+func (h *DepartamentoHandler) Create(c *gin.Context) {
+	var req model.DepartamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	departamento, err := h.departamentoService.Create(req)
+	if err != nil {
+		respondDepartamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, departamento)
+}
+
+// Update - Editar ou desativar departamento (admin)
+func (h *DepartamentoHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.DepartamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	departamento, err := h.departamentoService.Update(id, req)
+	if err != nil {
+		respondDepartamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, departamento)
+}
+
+// Delete - Excluir departamento que nunca teve colaboradores (admin)
+func (h *DepartamentoHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.departamentoService.Delete(id); err != nil {
+		respondDepartamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Departamento excluído com sucesso"})
+}
+
+func respondDepartamentoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrDepartamentoNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDepartamentoNomeEmUso), errors.Is(err, service.ErrDepartamentoEmUso):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNomeObrigatorio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar departamento: " + err.Error()})
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type DesligamentoHandler struct {
+	desligamentoService *service.DesligamentoService
+}
+
+func NewDesligamentoHandler(desligamentoService *service.DesligamentoService) *DesligamentoHandler {
+	return &DesligamentoHandler{desligamentoService: desligamentoService}
+}
+
+// Desligar - Desligar o colaborador e retornar o checklist do que foi feito (RH)
+func (h *DesligamentoHandler) Desligar(c *gin.Context) {
+	usuarioID, _ := middleware.CurrentUser(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.DesligamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	desligamento, err := h.desligamentoService.Desligar(id, req, usuarioID)
+	if err != nil {
+		respondDesligamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, desligamento)
+}
+
+// List - Listar os desligamentos do colaborador com os relatórios (RH)
+func (h *DesligamentoHandler) List(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	desligamentos, err := h.desligamentoService.List(id)
+	if err != nil {
+		respondDesligamentoError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, desligamentos)
+}
+
+func respondDesligamentoError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrColaboradorNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrColaboradorJaDesligado), errors.Is(err, service.ErrSubstitutoAbaixo):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDesligamentoProprio):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrMotivoObrigatorio), errors.Is(err, service.ErrDesligamentoDataInvalida),
+		errors.Is(err, service.ErrDesligamentoDataFutura), errors.Is(err, service.ErrDesligamentoAntesAdmissao),
+		errors.Is(err, service.ErrPontoDesdeInvalido), errors.Is(err, service.ErrSubstitutoNaoEncontrado),
+		errors.Is(err, service.ErrSubstitutoDesligado), errors.Is(err, service.ErrSubstitutoProprio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao desligar colaborador: " + err.Error()})
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -150,15 +151,10 @@ func (h *DocumentoHandler) Create(c *gin.Context) {
 
 // List - Listar documentos com filtros
 func (h *DocumentoHandler) List(c *gin.Context) {
-	// Obter ID e cargo do colaborador
+	// Obter ID do colaborador
+	verTodos := podeVerTodosDocumentos(c)
 	colaboradorID, err := middleware.CurrentUser(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
-		return
-	}
-
-	cargoID, exists := c.Get("cargo_id")
-	if !exists {
+	if err != nil && !verTodos {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
 		return
 	}
@@ -168,24 +164,27 @@ func (h *DocumentoHandler) List(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	// Verificar se é admin (cargo 2, 5 ou 6)
-	isAdmin := cargoID.(int) == 2 || cargoID.(int) == 5 || cargoID.(int) == 6
+	filtro := model.DocumentoFiltro{Status: status, Limit: limit, Offset: offset}
+	filtro.DepartamentoID, filtro.CentroCustoID, err = parseFiltroAlocacao(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	var colaboradorIDPtr *int
-	if isAdmin {
+	if verTodos {
 		// Admin pode ver todos documentos ou filtrar por colaborador
 		if filtroColaboradorID := c.Query("colaborador_id"); filtroColaboradorID != "" {
 			id, err := strconv.Atoi(filtroColaboradorID)
 			if err == nil {
-				colaboradorIDPtr = &id
+				filtro.ColaboradorID = &id
 			}
 		}
 	} else {
 		// Colaborador normal só vê seus próprios documentos
-		colaboradorIDPtr = &colaboradorID
+		filtro.ColaboradorID = &colaboradorID
 	}
 
-	documentos, err := h.documentoService.List(colaboradorIDPtr, status, limit, offset)
+	documentos, err := h.documentoService.List(filtro)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar documentos: " + err.Error()})
 		return
@@ -196,15 +195,10 @@ func (h *DocumentoHandler) List(c *gin.Context) {
 
 // GetByID - Obter documento por ID
 func (h *DocumentoHandler) GetByID(c *gin.Context) {
-	// Obter ID e cargo do colaborador
+	// Obter ID do colaborador
+	verTodos := podeVerTodosDocumentos(c)
 	colaboradorID, err := middleware.CurrentUser(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
-		return
-	}
-
-	cargoID, exists := c.Get("cargo_id")
-	if !exists {
+	if err != nil && !verTodos {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
 		return
 	}
@@ -224,8 +218,7 @@ func (h *DocumentoHandler) GetByID(c *gin.Context) {
 	}
 
 	// Verificar permissão
-	isAdmin := cargoID.(int) == 2 || cargoID.(int) == 5 || cargoID.(int) == 6
-	if !isAdmin && documento.ColaboradorID != colaboradorID {
+	if !verTodos && !h.documentoService.PodeVer(documento, colaboradorID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
 		return
 	}
@@ -233,17 +226,36 @@ func (h *DocumentoHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, documento)
 }
 
-// Aprovar - Aprovar documento
-func (h *DocumentoHandler) Aprovar(c *gin.Context) {
-	// Verificar se é admin
-	cargoID, exists := c.Get("cargo_id")
-	if !exists || (cargoID.(int) != 2 && cargoID.(int) != 5 && cargoID.(int) != 6) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
+// ListPendentesAprovacao - Listar documentos que aguardam a decisão do
+// usuário logado, como gestor ou como RH
+func (h *DocumentoHandler) ListPendentesAprovacao(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
 		return
 	}
 
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	rh := middleware.HasPermission(c, service.PermDocumentosAprovar)
+
+	documentos, err := h.documentoService.ListPendentesAprovacao(colaboradorID, rh, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar documentos: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, documentos)
+}
+
+// Aprovar - Aprovar documento (gestor de quem enviou ou RH)
+func (h *DocumentoHandler) Aprovar(c *gin.Context) {
 	// Obter ID do colaborador
-	colaboradorID, _ := middleware.CurrentUser(c)
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
+		return
+	}
 
 	// Obter ID do documento
 	id, err := strconv.Atoi(c.Param("id"))
@@ -252,26 +264,24 @@ func (h *DocumentoHandler) Aprovar(c *gin.Context) {
 		return
 	}
 
-	if err := h.documentoService.UpdateStatus(id, "aprovado", colaboradorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao aprovar documento: " + err.Error()})
+	rh := middleware.HasPermission(c, service.PermDocumentosAprovar)
+	if err := h.documentoService.Decidir(id, "aprovado", colaboradorID, rh); err != nil {
+		respondDecisaoError(c, "Erro ao aprovar documento: ", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Documento aprovado com sucesso"})
 }
 
-// Rejeitar - Rejeitar documento
+// Rejeitar - Rejeitar documento (gestor de quem enviou ou RH)
 func (h *DocumentoHandler) Rejeitar(c *gin.Context) {
-	// Verificar se é admin
-	cargoID, exists := c.Get("cargo_id")
-	if !exists || (cargoID.(int) != 2 && cargoID.(int) != 5 && cargoID.(int) != 6) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
+	// Obter ID do colaborador
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
 		return
 	}
 
-	// Obter ID do colaborador
-	colaboradorID, _ := middleware.CurrentUser(c)
-
 	// Obter ID do documento
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -289,8 +299,9 @@ func (h *DocumentoHandler) Rejeitar(c *gin.Context) {
 		return
 	}
 
-	if err := h.documentoService.UpdateStatus(id, "rejeitado", colaboradorID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao rejeitar documento: " + err.Error()})
+	rh := middleware.HasPermission(c, service.PermDocumentosAprovar)
+	if err := h.documentoService.Decidir(id, "rejeitado", colaboradorID, rh); err != nil {
+		respondDecisaoError(c, "Erro ao rejeitar documento: ", err)
 		return
 	}
 
@@ -299,13 +310,6 @@ func (h *DocumentoHandler) Rejeitar(c *gin.Context) {
 
 // Enviar - Marcar como enviado para finanças
 func (h *DocumentoHandler) Enviar(c *gin.Context) {
-	// Verificar se é admin
-	cargoID, exists := c.Get("cargo_id")
-	if !exists || (cargoID.(int) != 2 && cargoID.(int) != 5 && cargoID.(int) != 6) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
-		return
-	}
-
 	// Obter ID do documento
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -333,15 +337,10 @@ func (h *DocumentoHandler) Enviar(c *gin.Context) {
 
 // Download - Fazer download do arquivo
 func (h *DocumentoHandler) Download(c *gin.Context) {
-	// Obter ID e cargo do colaborador
+	// Obter ID do colaborador
+	verTodos := podeVerTodosDocumentos(c)
 	colaboradorID, err := middleware.CurrentUser(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
-		return
-	}
-
-	cargoID, exists := c.Get("cargo_id")
-	if !exists {
+	if err != nil && !verTodos {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Não autorizado"})
 		return
 	}
@@ -361,8 +360,7 @@ func (h *DocumentoHandler) Download(c *gin.Context) {
 	}
 
 	// Verificar permissão
-	isAdmin := cargoID.(int) == 2 || cargoID.(int) == 5 || cargoID.(int) == 6
-	if !isAdmin && documento.ColaboradorID != colaboradorID {
+	if !verTodos && !h.documentoService.PodeVer(documento, colaboradorID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
 		return
 	}
@@ -382,3 +380,24 @@ func (h *DocumentoHandler) Download(c *gin.Context) {
 	c.Header("Content-Type", documento.MimeType)
 	c.File(documento.CaminhoArquivo)
 }
+
+func respondDecisaoError(c *gin.Context, prefixo string, err error) {
+	switch {
+	case errors.Is(err, service.ErrDocumentoNaoEncontrado):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Documento não encontrado"})
+	case errors.Is(err, service.ErrDocumentoProprio), errors.Is(err, service.ErrDocumentoOutroAprovador),
+		errors.Is(err, service.ErrDocumentoSem2FA):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDocumentoJaDecidido):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": prefixo + err.Error()})
+	}
+}
+
+// podeVerTodosDocumentos informa se a requisição pode acessar documentos de
+// qualquer colaborador: usuários com a permissão ou contas de serviço, cujo
+// escopo já foi verificado na rota
+func podeVerTodosDocumentos(c *gin.Context) bool {
+	return middleware.IsServiceAccount(c) || middleware.HasPermission(c, service.PermDocumentosVerTodos)
+}
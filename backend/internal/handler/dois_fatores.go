@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type DoisFatoresHandler struct {
+	doisFatoresService *service.DoisFatoresService
+}
+
+func NewDoisFatoresHandler(doisFatoresService *service.DoisFatoresService) *DoisFatoresHandler {
+	return &DoisFatoresHandler{doisFatoresService: doisFatoresService}
+}
+
+// Cadastrar - Gerar segredo TOTP e URI otpauth:// para o usuário logado
+func (h *DoisFatoresHandler) Cadastrar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.doisFatoresService.IniciarCadastro(colaboradorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao iniciar cadastro do 2FA: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Ativar - Confirmar o cadastro com um código do autenticador
+func (h *DoisFatoresHandler) Ativar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req model.DoisFatoresCodigoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	codigos, err := h.doisFatoresService.Ativar(colaboradorID, req.Codigo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ativar 2FA: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.DoisFatoresAtivacaoResponse{CodigosRecuperacao: codigos})
+}
+
+// Desativar - Remover o 2FA (quando não exigido pelo cargo)
+func (h *DoisFatoresHandler) Desativar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	cargoID, _ := c.Get("cargo_id")
+
+	var req model.DoisFatoresCodigoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.doisFatoresService.Desativar(colaboradorID, cargoID.(int), req.Codigo); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao desativar 2FA: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "2FA desativado com sucesso"})
+}
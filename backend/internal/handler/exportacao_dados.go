@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+)
+
+type ExportacaoDadosHandler struct {
+	exportacaoService *service.ExportacaoDadosService
+}
+
+func NewExportacaoDadosHandler(exportacaoService *service.ExportacaoDadosService) *ExportacaoDadosHandler {
+	return &ExportacaoDadosHandler{exportacaoService: exportacaoService}
+}
+
+// Solicitar - Exportar todos os dados pessoais do usuário logado (LGPD). O
+// ZIP é gerado em segundo plano: enquanto não fica pronto, responde 202 e o
+// app consulta de novo; pronto, responde 200 com o link de download.
+func (h *ExportacaoDadosHandler) Solicitar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	exportacao, err := h.exportacaoService.Solicitar(colaboradorID, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao exportar dados"})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	if exportacao.Status == service.ExportacaoConcluida {
+		c.JSON(http.StatusOK, gin.H{"exportacao": exportacao})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"exportacao": exportacao})
+}
+
+// Baixar - Download do ZIP pelo link assinado (não exige login, o link vale
+// até expirar)
+func (h *ExportacaoDadosHandler) Baixar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	exportacao, err := h.exportacaoService.Arquivo(id, c.Query("expira"), c.Query("assinatura"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExportacaoLinkInvalido), errors.Is(err, service.ErrExportacaoNaoEncontrada):
+			c.JSON(http.StatusNotFound, gin.H{"error": service.ErrExportacaoLinkInvalido.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar exportação"})
+		}
+		return
+	}
+
+	fileName := fmt.Sprintf("dados-pessoais-%s.zip", exportacao.CriadoEm.Format("2006-01-02"))
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+fileName)
+	c.Header("Content-Type", "application/zip")
+	c.File(exportacao.CaminhoArquivo)
+}
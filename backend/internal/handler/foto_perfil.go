@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+	"empresa-app/backend/pkg/imagem"
+)
+
+type FotoPerfilHandler struct {
+	fotoService *service.FotoPerfilService
+}
+
+func NewFotoPerfilHandler(fotoService *service.FotoPerfilService) *FotoPerfilHandler {
+	return &FotoPerfilHandler{fotoService: fotoService}
+}
+
+// Enviar - Enviar foto de perfil do usuário logado (JPEG ou PNG)
+func (h *FotoPerfilHandler) Enviar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("foto")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado"})
+		return
+	}
+
+	arquivo, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao processar arquivo"})
+		return
+	}
+	defer arquivo.Close()
+
+	url, err := h.fotoService.Enviar(colaboradorID, arquivo)
+	if err != nil {
+		switch {
+		case errors.Is(err, imagem.ErrFormatoInvalido), errors.Is(err, imagem.ErrImagemGrande), errors.Is(err, service.ErrFotoGrande):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar foto: " + err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"foto_perfil": url})
+}
+
+// Remover - Remover foto de perfil do usuário logado
+func (h *FotoPerfilHandler) Remover(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.fotoService.Remover(colaboradorID); err != nil {
+		if errors.Is(err, service.ErrFotoNaoEncontrada) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover foto: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Foto removida com sucesso"})
+}
+
+// Obter - Servir a foto de um colaborador (tamanho=grande ou pequena)
+func (h *FotoPerfilHandler) Obter(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	foto, err := h.fotoService.Obter(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	tamanho := c.DefaultQuery("tamanho", "grande")
+	caminho := foto.CaminhoGrande
+	switch tamanho {
+	case "grande":
+	case "pequena":
+		caminho = foto.CaminhoPequeno
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tamanho inválido (use grande ou pequena)"})
+		return
+	}
+
+	// A URL muda a cada nova foto (?v=versao); o cache é privado porque a
+	// rota exige autenticação
+	etag := `"` + foto.Versao + "-" + tamanho + `"`
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.Header("ETag", etag)
+	c.Header("Vary", "Authorization")
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Header("Content-Type", "image/jpeg")
+	c.File(caminho)
+}
@@ -1,8 +1,26 @@
 package handler
 
 type Handlers struct {
-	Auth        *AuthHandler
-	Colaborador *ColaboradorHandler
-	Documento   *DocumentoHandler
-	Ponto       *PontoHandler
+	Auth            *AuthHandler
+	Colaborador     *ColaboradorHandler
+	Documento       *DocumentoHandler
+	Ponto           *PontoHandler
+	Permissao       *PermissaoHandler
+	Redefinicao     *RedefinicaoSenhaHandler
+	DoisFatores     *DoisFatoresHandler
+	Tentativa       *TentativaLoginHandler
+	ContaServico    *ContaServicoHandler
+	Sessao          *SessaoHandler
+	Personificacao  *PersonificacaoHandler
+	OIDC            *OIDCHandler
+	Cargo           *CargoHandler
+	FotoPerfil      *FotoPerfilHandler
+	DadosBancarios  *DadosBancariosHandler
+	Importacao      *ImportacaoHandler
+	Departamento    *DepartamentoHandler
+	CentroCusto     *CentroCustoHandler
+	Alocacao        *AlocacaoHandler
+	Desligamento    *DesligamentoHandler
+	ExportacaoDados *ExportacaoDadosHandler
+	Anonimizacao    *AnonimizacaoHandler
 }
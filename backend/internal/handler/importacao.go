@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+	"empresa-app/backend/pkg/planilha"
+)
+
+type ImportacaoHandler struct {
+	importacaoService *service.ImportacaoService
+}
+
+func NewImportacaoHandler(importacaoService *service.ImportacaoService) *ImportacaoHandler {
+	return &ImportacaoHandler{importacaoService: importacaoService}
+}
+
+// Importar - Cadastrar colaboradores a partir de uma planilha CSV ou XLSX (RH).
+// Sem ?confirmar=true, apenas valida e devolve o relatório de cada linha.
+func (h *ImportacaoHandler) Importar(c *gin.Context) {
+	file, err := c.FormFile("arquivo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado"})
+		return
+	}
+
+	arquivo, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao processar arquivo"})
+		return
+	}
+	defer arquivo.Close()
+
+	confirmar := c.Query("confirmar") == "true"
+
+	importadoPor, _ := middleware.CurrentUser(c)
+	relatorio, err := h.importacaoService.Importar(arquivo, confirmar, importadoPor)
+	if err != nil {
+		var colunasErr *service.ImportacaoColunasError
+		switch {
+		case errors.Is(err, service.ErrImportacaoComErros):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "relatorio": relatorio})
+		case errors.Is(err, planilha.ErrFormatoInvalido),
+			errors.Is(err, service.ErrImportacaoArquivoGrande),
+			errors.Is(err, service.ErrImportacaoVazia),
+			errors.Is(err, service.ErrImportacaoMuitasLinhas),
+			errors.As(err, &colunasErr):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao importar colaboradores: " + err.Error()})
+		}
+		return
+	}
+
+	if confirmar {
+		c.JSON(http.StatusCreated, relatorio)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/service"
+)
+
+// Cookie que liga o retorno do provedor ao navegador que iniciou o login,
+// impedindo que um retorno obtido por outra pessoa seja usado
+const (
+	cookieStateOIDC   = "oidc_state"
+	caminhoCookieOIDC = "/api/auth/oidc"
+)
+
+type OIDCHandler struct {
+	oidcService  *service.OIDCService
+	cookieSeguro bool
+}
+
+func NewOIDCHandler(oidcService *service.OIDCService, cookieSeguro bool) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcService, cookieSeguro: cookieSeguro}
+}
+
+// Iniciar - Redirecionar para o login no provedor de identidade
+func (h *OIDCHandler) Iniciar(c *gin.Context) {
+	authURL, state, err := h.oidcService.Iniciar(c.Request.Context())
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	// SameSite=Lax para o cookie acompanhar o redirecionamento de volta do provedor
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cookieStateOIDC, state, 600, caminhoCookieOIDC, "", h.cookieSeguro, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback - Concluir o login com o código retornado pelo provedor
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cookieStateOIDC, "", -1, caminhoCookieOIDC, "", h.cookieSeguro, true)
+
+	if erro := c.Query("error"); erro != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Login recusado pelo provedor de identidade: " + erro})
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Parâmetros code e state são obrigatórios"})
+		return
+	}
+
+	cookie, err := c.Cookie(cookieStateOIDC)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		respondOIDCError(c, service.ErrOIDCAutorizacaoInvalida)
+		return
+	}
+
+	response, err := h.oidcService.Concluir(c.Request.Context(), state, code, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+func respondOIDCError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrOIDCDesativado):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOIDCAutorizacaoInvalida):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOIDCProvedor):
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOIDCContaAmbigua):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrOIDCEmailNaoVerificado),
+		errors.Is(err, service.ErrOIDCNaoCadastrado),
+		errors.Is(err, service.ErrColaboradorSemAcesso):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao concluir login: " + err.Error()})
+	}
+}
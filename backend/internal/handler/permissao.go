@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type PermissaoHandler struct {
+	permissaoService *service.PermissaoService
+}
+
+func NewPermissaoHandler(permissaoService *service.PermissaoService) *PermissaoHandler {
+	return &PermissaoHandler{permissaoService: permissaoService}
+}
+
+// List - Listar permissões disponíveis
+func (h *PermissaoHandler) List(c *gin.Context) {
+	permissoes, err := h.permissaoService.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar permissões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, permissoes)
+}
+
+// GetCargo - Obter cargo com suas permissões
+func (h *PermissaoHandler) GetCargo(c *gin.Context) {
+	cargoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	cargo, err := h.permissaoService.GetCargo(cargoID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cargo)
+}
+
+// Grant - Conceder permissão a um cargo
+func (h *PermissaoHandler) Grant(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	cargoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.PermissaoGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.permissaoService.Grant(cargoID, req.Permissao, colaboradorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao conceder permissão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Permissão concedida com sucesso"})
+}
+
+// Revoke - Remover permissão de um cargo
+func (h *PermissaoHandler) Revoke(c *gin.Context) {
+	colaboradorID, _ := middleware.CurrentUser(c)
+
+	cargoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.permissaoService.Revoke(cargoID, c.Param("permissao"), colaboradorID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao remover permissão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Permissão removida com sucesso"})
+}
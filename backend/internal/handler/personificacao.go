@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type PersonificacaoHandler struct {
+	personificacaoService *service.PersonificacaoService
+}
+
+func NewPersonificacaoHandler(personificacaoService *service.PersonificacaoService) *PersonificacaoHandler {
+	return &PersonificacaoHandler{personificacaoService: personificacaoService}
+}
+
+// Iniciar - Emitir token de personificação de um colaborador (admin)
+func (h *PersonificacaoHandler) Iniciar(c *gin.Context) {
+	atorID, _ := middleware.CurrentUser(c)
+
+	alvoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var req model.PersonificacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Motivo é obrigatório"})
+		return
+	}
+
+	response, err := h.personificacaoService.Iniciar(atorID, alvoID, req.Motivo, c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao iniciar personificação: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// List - Listar personificações (admin)
+func (h *PersonificacaoHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	personificacoes, err := h.personificacaoService.List(limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar personificações: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, personificacoes)
+}
+
+// ListRequisicoes - Listar requisições feitas durante a personificação (admin)
+func (h *PersonificacaoHandler) ListRequisicoes(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	requisicoes, err := h.personificacaoService.ListRequisicoes(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar requisições: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requisicoes)
+}
+
+// Encerrar - Encerrar personificação antes do prazo (admin)
+func (h *PersonificacaoHandler) Encerrar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.personificacaoService.Encerrar(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Personificação encerrada com sucesso"})
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -97,7 +98,32 @@ func (h *PontoHandler) Listar(c *gin.Context) {
 		data = time.Now()
 	}
 
-	pontos, err := h.pontoService.Listar(colaboradorID, data)
+	filtro := model.PontoFiltro{ColaboradorID: &colaboradorID, Data: data}
+	filtro.DepartamentoID, filtro.CentroCustoID, err = parseFiltroAlocacao(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Com permissão de equipe, é possível consultar outro colaborador ou todos
+	// os alocados no departamento ou centro de custo
+	if filtroColaboradorID := c.Query("colaborador_id"); filtroColaboradorID != "" {
+		if !middleware.HasPermission(c, service.PermPontoVerEquipe) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
+			return
+		}
+
+		id, err := strconv.Atoi(filtroColaboradorID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID de colaborador inválido"})
+			return
+		}
+		filtro.ColaboradorID = &id
+	} else if (filtro.DepartamentoID != 0 || filtro.CentroCustoID != 0) && middleware.HasPermission(c, service.PermPontoVerEquipe) {
+		filtro.ColaboradorID = nil
+	}
+
+	pontos, err := h.pontoService.Listar(filtro)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar pontos: " + err.Error()})
 		return
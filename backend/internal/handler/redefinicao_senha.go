@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/service"
+)
+
+type RedefinicaoSenhaHandler struct {
+	redefinicaoService *service.RedefinicaoSenhaService
+}
+
+func NewRedefinicaoSenhaHandler(redefinicaoService *service.RedefinicaoSenhaService) *RedefinicaoSenhaHandler {
+	return &RedefinicaoSenhaHandler{redefinicaoService: redefinicaoService}
+}
+
+// Solicitar - Enviar email com link de redefinição de senha
+func (h *RedefinicaoSenhaHandler) Solicitar(c *gin.Context) {
+	var req model.EsqueciSenhaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.redefinicaoService.Solicitar(req.Email, c.ClientIP()); err != nil {
+		var limitada *service.RedefinicaoLimitadaError
+		if errors.As(err, &limitada) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(limitada.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao solicitar redefinição de senha"})
+		return
+	}
+
+	// Mesma resposta para emails existentes ou não
+	c.JSON(http.StatusOK, gin.H{"message": "Se o email estiver cadastrado, você receberá um link para redefinir a senha"})
+}
+
+// Redefinir - Definir nova senha com o token recebido por email
+func (h *RedefinicaoSenhaHandler) Redefinir(c *gin.Context) {
+	var req model.RedefinirSenhaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		return
+	}
+
+	if err := h.redefinicaoService.Redefinir(req.Token, req.NovaSenha); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao redefinir senha: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso"})
+}
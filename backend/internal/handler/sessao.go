@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+)
+
+type SessaoHandler struct {
+	sessaoService *service.SessaoService
+}
+
+func NewSessaoHandler(sessaoService *service.SessaoService) *SessaoHandler {
+	return &SessaoHandler{sessaoService: sessaoService}
+}
+
+// Listar - Listar sessões ativas do usuário logado
+func (h *SessaoHandler) Listar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessoes, err := h.sessaoService.Listar(colaboradorID, middleware.CurrentSession(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar sessões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessoes)
+}
+
+// Encerrar - Encerrar uma sessão do usuário logado
+func (h *SessaoHandler) Encerrar(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.sessaoService.Encerrar(colaboradorID, id); err != nil {
+		if errors.Is(err, service.ErrSessaoNaoEncontrada) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao encerrar sessão: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessão encerrada com sucesso"})
+}
+
+// ListarColaborador - Listar sessões ativas de um colaborador (admin)
+func (h *SessaoHandler) ListarColaborador(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	sessoes, err := h.sessaoService.Listar(id, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar sessões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessoes)
+}
+
+// EncerrarTodas - Encerrar todas as sessões de um colaborador (admin)
+func (h *SessaoHandler) EncerrarTodas(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.sessaoService.EncerrarTodas(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao encerrar sessões: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessões do colaborador encerradas com sucesso"})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/middleware"
+	"empresa-app/backend/internal/service"
+)
+
+type TentativaLoginHandler struct {
+	tentativaService *service.TentativaLoginService
+}
+
+func NewTentativaLoginHandler(tentativaService *service.TentativaLoginService) *TentativaLoginHandler {
+	return &TentativaLoginHandler{tentativaService: tentativaService}
+}
+
+// ListarHistorico - Listar histórico de login do usuário logado
+func (h *TentativaLoginHandler) ListarHistorico(c *gin.Context) {
+	colaboradorID, err := middleware.CurrentUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	historico, err := h.tentativaService.ListarHistorico(colaboradorID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao listar histórico de login: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, historico)
+}
+
+// Desbloquear - Remover bloqueio de login de um colaborador (admin)
+func (h *TentativaLoginHandler) Desbloquear(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := h.tentativaService.Desbloquear(id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Conta desbloqueada com sucesso"})
+}
@@ -3,25 +3,68 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"empresa-app/backend/internal/model"
 	"empresa-app/backend/internal/service"
 )
 
 var authService *service.AuthService
+var contaServicoService *service.ContaServicoService
+var sessaoService *service.SessaoService
+var statusService *service.StatusColaboradorService
+var personificacaoService *service.PersonificacaoService
 
 func SetAuthService(svc *service.AuthService) {
 	authService = svc
 }
 
+func SetContaServicoService(svc *service.ContaServicoService) {
+	contaServicoService = svc
+}
+
+func SetSessaoService(svc *service.SessaoService) {
+	sessaoService = svc
+}
+
+func SetStatusColaboradorService(svc *service.StatusColaboradorService) {
+	statusService = svc
+}
+
+func SetPersonificacaoService(svc *service.PersonificacaoService) {
+	personificacaoService = svc
+}
+
+// AuthMiddleware autentica colaboradores pelo token de acesso. Chaves de API
+// são recusadas: apenas as rotas com AuthMiddlewareComEscopo as aceitam.
 func AuthMiddleware() gin.HandlerFunc {
+	return autenticar("")
+}
+
+// AuthMiddlewareComEscopo autentica como AuthMiddleware e também aceita chaves
+// de API de contas de serviço que possuam o escopo informado
+func AuthMiddlewareComEscopo(escopo string) gin.HandlerFunc {
+	return autenticar(escopo)
+}
+
+// autenticar valida o token de acesso ou, se escopo não for vazio, a chave de
+// API com esse escopo
+func autenticar(escopo string) gin.HandlerFunc {
 	if authService == nil {
 		panic("AuthService não foi configurado para o middleware")
 	}
 
 	return func(c *gin.Context) {
+		// Chave de API de conta de serviço (cabeçalho X-API-Key)
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			authenticateAPIKey(c, apiKey, escopo)
+			return
+		}
+
 		// Obter token do cabeçalho
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -45,6 +88,12 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Chave de API enviada como "Bearer rls_..."
+		if strings.HasPrefix(token, service.PrefixoChaveAPI) {
+			authenticateAPIKey(c, token, escopo)
+			return
+		}
+
 		// Validar token
 		claims, err := authService.ValidateToken(token)
 		if err != nil {
@@ -68,33 +117,185 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		jti, _ := claims["jti"].(string)
+		exp, _ := claims["exp"].(float64)
+		sessaoID, _ := claims["sid"].(float64)
+
 		// Armazenar dados no contexto
 		c.Set("colaborador_id", int(userID))
 		c.Set("cargo_id", int(cargoID))
+		c.Set("jti", jti)
+		c.Set("token_exp", time.Unix(int64(exp), 0))
+		c.Set("sessao_id", int(sessaoID))
+
+		// Token de personificação: o administrador age como o colaborador
+		if act, ok := claims["act"].(map[string]interface{}); ok {
+			atorID, _ := act["id"].(float64)
+			personificacaoID, _ := claims["personificacao"].(float64)
+			c.Set("ator_id", int(atorID))
+			c.Set("personificacao_id", int(personificacaoID))
+
+			executarPersonificado(c, int(userID), int(atorID), int(personificacaoID))
+			return
+		}
+
+		// Aplicar as regras de acesso do status do colaborador
+		if statusService != nil && !verificarStatus(c, int(userID)) {
+			return
+		}
+
+		// Atualizar o último acesso da sessão
+		if sessaoService != nil {
+			sessaoService.RegistrarAcesso(int(sessaoID), c.ClientIP())
+		}
 
 		c.Next()
 	}
 }
 
-func AdminMiddleware() gin.HandlerFunc {
+// executarPersonificado registra a requisição antes de executá-la e grava o
+// status da resposta ao final. O registro precede a verificação do
+// administrador e do status do colaborador, para que as requisições recusadas
+// também fiquem registradas.
+func executarPersonificado(c *gin.Context, colaboradorID, atorID, personificacaoID int) {
+	if personificacaoService == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Personificação não suportada"})
+		c.Abort()
+		return
+	}
+
+	requisicao := &model.RequisicaoPersonificacao{
+		PersonificacaoID: personificacaoID,
+		Metodo:           c.Request.Method,
+		Rota:             c.FullPath(),
+		Caminho:          c.Request.URL.RequestURI(),
+		IP:               c.ClientIP(),
+	}
+	if err := personificacaoService.RegistrarRequisicao(requisicao); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar requisição da personificação"})
+		c.Abort()
+		return
+	}
+
+	if verificarAtor(c, atorID) && (statusService == nil || verificarStatus(c, colaboradorID)) {
+		c.Next()
+	}
+
+	personificacaoService.ConcluirRequisicao(requisicao.ID, c.Writer.Status())
+}
+
+// verificarAtor aborta a requisição se o administrador que personifica o
+// colaborador não tiver mais acesso ou a permissão de personificar
+func verificarAtor(c *gin.Context, atorID int) bool {
+	err := personificacaoService.VerificarAtor(atorID)
+	if errors.Is(err, service.ErrAtorSemAcesso) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Abort()
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar o administrador da personificação"})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// BloquearPersonificacao impede ações sensíveis (senha, 2FA, dados bancários,
+// sessões) com tokens de personificação
+func BloquearPersonificacao() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Verificar se é admin (cargo 2, 5 ou 6)
-		cargoID, exists := c.Get("cargo_id")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+		if IsImpersonating(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Ação não permitida durante a personificação"})
 			c.Abort()
 			return
 		}
 
-		isAdmin := cargoID.(int) == 2 || cargoID.(int) == 5 || cargoID.(int) == 6
-		if !isAdmin {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão de administrador"})
+		c.Next()
+	}
+}
+
+// IsImpersonating informa se a requisição usa um token de personificação
+func IsImpersonating(c *gin.Context) bool {
+	_, exists := c.Get("ator_id")
+	return exists
+}
+
+// rotasAcessoSomenteLeitura lista as rotas de escrita liberadas para
+// colaboradores com acesso somente leitura (férias e afastamento)
+var rotasAcessoSomenteLeitura = map[string]bool{
+	"POST /api/auth/logout":      true,
+	"PUT /api/me/senha":          true,
+	"DELETE /api/me/sessoes/:id": true,
+	"POST /api/documentos":       true, // atestados e recibos
+}
+
+// verificarStatus aborta a requisição se o status atual do colaborador não
+// permitir o acesso à rota
+func verificarStatus(c *gin.Context, colaboradorID int) bool {
+	acesso, err := statusService.Acesso(colaboradorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar status do colaborador"})
+		c.Abort()
+		return false
+	}
+
+	switch acesso {
+	case service.AcessoNegado:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": service.ErrColaboradorSemAcesso.Error()})
+		c.Abort()
+		return false
+	case service.AcessoSomenteLeitura:
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return true
+		}
+		if !rotasAcessoSomenteLeitura[c.Request.Method+" "+c.FullPath()] {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Acesso somente leitura durante férias ou afastamento"})
 			c.Abort()
-			return
+			return false
 		}
+	}
 
-		c.Next()
+	return true
+}
+
+// authenticateAPIKey autentica uma conta de serviço e exige o escopo da rota;
+// sem escopo declarado, a rota não aceita chaves de API. Apenas
+// conta_servico_id e escopos são definidos no contexto: sem colaborador_id e
+// cargo_id, as rotas de usuário (CurrentUser) e as protegidas por permissão
+// recusam a requisição.
+func authenticateAPIKey(c *gin.Context, apiKey, escopo string) {
+	if contaServicoService == nil || escopo == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Chaves de API não são aceitas nesta rota"})
+		c.Abort()
+		return
 	}
+
+	chave, err := contaServicoService.Autenticar(apiKey)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Abort()
+		return
+	}
+
+	if !slices.Contains(chave.Escopos, escopo) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Escopo insuficiente: " + escopo})
+		c.Abort()
+		return
+	}
+
+	c.Set("conta_servico_id", chave.ContaServicoID)
+	c.Set("escopos", chave.Escopos)
+
+	c.Next()
+}
+
+// IsServiceAccount informa se a requisição foi autenticada por chave de API
+func IsServiceAccount(c *gin.Context) bool {
+	_, exists := c.Get("conta_servico_id")
+	return exists
 }
 
 func CurrentUser(c *gin.Context) (int, error) {
@@ -104,3 +305,21 @@ func CurrentUser(c *gin.Context) (int, error) {
 	}
 	return userID.(int), nil
 }
+
+// CurrentToken retorna o jti e a expiração do token de acesso da requisição
+func CurrentToken(c *gin.Context) (string, time.Time, error) {
+	jti, exists := c.Get("jti")
+	if !exists {
+		return "", time.Time{}, errors.New("usuário não autenticado")
+	}
+	exp, _ := c.Get("token_exp")
+	expiraEm, _ := exp.(time.Time)
+	return jti.(string), expiraEm, nil
+}
+
+// CurrentSession retorna o ID da sessão do token de acesso (0 se não houver)
+func CurrentSession(c *gin.Context) int {
+	sessaoID, _ := c.Get("sessao_id")
+	id, _ := sessaoID.(int)
+	return id
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"empresa-app/backend/internal/service"
+)
+
+var permissaoService *service.PermissaoService
+
+func SetPermissaoService(svc *service.PermissaoService) {
+	permissaoService = svc
+}
+
+// RequirePermission exige que o cargo do usuário autenticado possua a
+// permissão. Ações protegidas por permissão não ficam disponíveis durante a
+// personificação.
+func RequirePermission(permissao string) gin.HandlerFunc {
+	if permissaoService == nil {
+		panic("PermissaoService não foi configurado para o middleware")
+	}
+
+	return func(c *gin.Context) {
+		if _, exists := c.Get("cargo_id"); !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuário não autenticado"})
+			c.Abort()
+			return
+		}
+
+		if IsImpersonating(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Ação não permitida durante a personificação"})
+			c.Abort()
+			return
+		}
+
+		if !HasPermission(c, permissao) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Sem permissão"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// HasPermission informa se o usuário autenticado possui a permissão
+func HasPermission(c *gin.Context, permissao string) bool {
+	cargoID, exists := c.Get("cargo_id")
+	if !exists || permissaoService == nil {
+		return false
+	}
+
+	return permissaoService.HasPermission(cargoID.(int), permissao)
+}
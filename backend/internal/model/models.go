@@ -1,22 +1,66 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Colaborador representa um funcionário da empresa
 type Colaborador struct {
-	ID             int       `json:"id"`
-	UUID           string    `json:"uuid"`
-	Nome           string    `json:"nome" binding:"required"`
-	Email          string    `json:"email" binding:"required,email"`
-	Senha          string    `json:"senha,omitempty"`
-	CargoID        int       `json:"cargo_id" binding:"required"`
-	DataAdmissao   time.Time `json:"data_admissao"`
-	Status         string    `json:"status"`
-	FotoPerfil     string    `json:"foto_perfil"`
-	Telefone       string    `json:"telefone"`
-	DadosBancarios []byte    `json:"dados_bancarios"`
-	CriadoEm       time.Time `json:"criado_em"`
-	AtualizadoEm   time.Time `json:"atualizado_em"`
+	ID             int             `json:"id"`
+	UUID           string          `json:"uuid"`
+	Nome           string          `json:"nome" binding:"required"`
+	Email          string          `json:"email" binding:"required,email"`
+	Senha          string          `json:"senha,omitempty"`
+	CargoID        int             `json:"cargo_id" binding:"required"`
+	CargoNome      string          `json:"cargo_nome"`
+	GestorID       *int            `json:"gestor_id"`
+	GestorNome     string          `json:"gestor_nome"`
+	DataAdmissao   time.Time       `json:"data_admissao"`
+	Status         string          `json:"status"`
+	FotoPerfil     string          `json:"foto_perfil"`
+	Telefone       string          `json:"telefone"`
+	DadosBancarios *DadosBancarios `json:"dados_bancarios"` // sempre mascarados
+	CriadoEm       time.Time       `json:"criado_em"`
+	AtualizadoEm   time.Time       `json:"atualizado_em"`
+
+	// Envelope cifrado como gravado no banco; nunca é serializado
+	DadosBancariosCifrados []byte `json:"-"`
+}
+
+// DadosBancarios são os dados para pagamento do colaborador. Ficam cifrados
+// no banco e são exibidos mascarados, exceto na revelação auditada.
+type DadosBancarios struct {
+	Banco    string `json:"banco"`   // código COMPE com 3 dígitos
+	Agencia  string `json:"agencia"` // com o dígito, se houver
+	Conta    string `json:"conta"`   // com o dígito, ex.: 12345-6
+	ChavePix string `json:"chave_pix"`
+}
+
+// RevelarDadosBancariosRequest justifica a consulta dos dados completos
+type RevelarDadosBancariosRequest struct {
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// RevelacaoDadosBancarios registra quem viu os dados bancários completos de
+// um colaborador
+type RevelacaoDadosBancarios struct {
+	ID              int       `json:"id"`
+	ColaboradorID   int       `json:"colaborador_id"`
+	ReveladoPor     *int      `json:"revelado_por"`
+	ReveladoPorNome string    `json:"revelado_por_nome"`
+	Motivo          string    `json:"motivo"`
+	IP              string    `json:"ip"`
+	CriadoEm        time.Time `json:"criado_em"`
+}
+
+// FotoPerfil guarda os caminhos das miniaturas da foto de um colaborador
+type FotoPerfil struct {
+	ColaboradorID  int
+	CaminhoGrande  string
+	CaminhoPequeno string
+	Versao         string
+	AtualizadoEm   time.Time
 }
 
 // Cargo representa um cargo na empresa
@@ -24,8 +68,120 @@ type Cargo struct {
 	ID           int       `json:"id"`
 	Nome         string    `json:"nome"`
 	Descricao    string    `json:"descricao"`
+	Exige2FA     bool      `json:"exige_2fa"`
+	Permissoes   []string  `json:"permissoes,omitempty"` // apenas na gestão de permissões
+	CriadoEm     time.Time `json:"criado_em"`
+	AtualizadoEm time.Time `json:"atualizado_em"`
+
+	TotalColaboradores int `json:"total_colaboradores"`
+}
+
+// CargoRequest representa o cadastro ou a edição de um cargo
+type CargoRequest struct {
+	Nome      string `json:"nome" binding:"required"`
+	Descricao string `json:"descricao"`
+	Exige2FA  bool   `json:"exige_2fa"`
+}
+
+// HistoricoCargo registra quem alterou um cargo e o que mudou
+type HistoricoCargo struct {
+	ID              int             `json:"id"`
+	CargoID         int             `json:"cargo_id"`
+	Acao            string          `json:"acao"`
+	AlteradoPor     *int            `json:"alterado_por"`
+	AlteradoPorNome string          `json:"alterado_por_nome"`
+	DadosAnteriores json.RawMessage `json:"dados_anteriores"`
+	DadosNovos      json.RawMessage `json:"dados_novos"`
+	CriadoEm        time.Time       `json:"criado_em"`
+}
+
+// HistoricoColaborador é uma alteração no cadastro de um colaborador. Os
+// dados anteriores e novos contêm apenas os campos alterados.
+type HistoricoColaborador struct {
+	ID              int             `json:"id"`
+	ColaboradorID   int             `json:"colaborador_id"`
+	Acao            string          `json:"acao"`
+	Campos          []string        `json:"campos"`
+	AlteradoPor     *int            `json:"alterado_por"`
+	AlteradoPorNome string          `json:"alterado_por_nome"`
+	DadosAnteriores json.RawMessage `json:"dados_anteriores"`
+	DadosNovos      json.RawMessage `json:"dados_novos"`
+	CriadoEm        time.Time       `json:"criado_em"`
+}
+
+// Departamento agrupa colaboradores na estrutura da empresa
+type Departamento struct {
+	ID           int       `json:"id"`
+	Nome         string    `json:"nome"`
+	Descricao    string    `json:"descricao"`
+	Ativo        bool      `json:"ativo"`
+	CriadoEm     time.Time `json:"criado_em"`
+	AtualizadoEm time.Time `json:"atualizado_em"`
+
+	TotalColaboradores int `json:"total_colaboradores"` // alocados hoje
+}
+
+// DepartamentoRequest representa o cadastro ou a edição de um departamento
+type DepartamentoRequest struct {
+	Nome      string `json:"nome" binding:"required"`
+	Descricao string `json:"descricao"`
+	Ativo     *bool  `json:"ativo"` // omitido: ativo no cadastro, mantido na edição
+}
+
+// CentroCusto é a unidade contábil à qual são atribuídas as despesas
+type CentroCusto struct {
+	ID           int       `json:"id"`
+	Codigo       string    `json:"codigo"`
+	Nome         string    `json:"nome"`
+	Ativo        bool      `json:"ativo"`
 	CriadoEm     time.Time `json:"criado_em"`
 	AtualizadoEm time.Time `json:"atualizado_em"`
+
+	TotalColaboradores int `json:"total_colaboradores"` // alocados hoje
+}
+
+// CentroCustoRequest representa o cadastro ou a edição de um centro de custo
+type CentroCustoRequest struct {
+	Codigo string `json:"codigo" binding:"required"`
+	Nome   string `json:"nome" binding:"required"`
+	Ativo  *bool  `json:"ativo"` // omitido: ativo no cadastro, mantido na edição
+}
+
+// AlocacaoColaborador indica o departamento e o centro de custo do
+// colaborador durante a vigência. VigenciaFim é o último dia (nulo enquanto
+// for a alocação atual).
+type AlocacaoColaborador struct {
+	ID               int        `json:"id"`
+	ColaboradorID    int        `json:"colaborador_id"`
+	DepartamentoID   *int       `json:"departamento_id"`
+	DepartamentoNome string     `json:"departamento_nome"`
+	CentroCustoID    *int       `json:"centro_custo_id"`
+	CentroCustoNome  string     `json:"centro_custo_nome"`
+	VigenciaInicio   time.Time  `json:"vigencia_inicio"`
+	VigenciaFim      *time.Time `json:"vigencia_fim"`
+	CriadoPor        *int       `json:"criado_por"`
+	CriadoPorNome    string     `json:"criado_por_nome"`
+	CriadoEm         time.Time  `json:"criado_em"`
+}
+
+// AlocacaoRequest aloca o colaborador a partir de vigencia_inicio
+type AlocacaoRequest struct {
+	DepartamentoID *int   `json:"departamento_id"`
+	CentroCustoID  *int   `json:"centro_custo_id"`
+	VigenciaInicio string `json:"vigencia_inicio" binding:"required"` // formato: 2006-01-02
+}
+
+// Permissao representa uma permissão que pode ser concedida a cargos
+type Permissao struct {
+	ID        int       `json:"id"`
+	Codigo    string    `json:"codigo"`
+	Descricao string    `json:"descricao"`
+	CriadoEm  time.Time `json:"criado_em"`
+}
+
+// PermissaoGrantRequest representa a concessão de uma permissão a um cargo
+type PermissaoGrantRequest struct {
+	Permissao string `json:"permissao" binding:"required"`
 }
 
 // Documento representa um documento ou recibo digitalizado
@@ -42,6 +198,7 @@ type Documento struct {
 	MimeType            string     `json:"mime_type"`
 	TamanhoBytes        int        `json:"tamanho_bytes"`
 	Status              string     `json:"status"`
+	AprovadorID         *int       `json:"aprovador_id"` // gestor que deve decidir; nulo fica com o RH
 	AprovadoPor         *int       `json:"aprovado_por"`
 	DataAprovacao       *time.Time `json:"data_aprovacao"`
 	EnviadoParaFinancas bool       `json:"enviado_para_financas"`
@@ -72,9 +229,436 @@ type LoginRequest struct {
 }
 
 // LoginResponse representa a resposta do login com o token
+// Quando o segundo fator é necessário, apenas Desafio é preenchido e o login
+// deve ser concluído em /api/auth/2fa/verificar (ou /api/auth/2fa/ativar, se
+// o cadastro do 2FA ainda estiver pendente)
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  *Colaborador `json:"user"`
+	Token               string       `json:"token,omitempty"`
+	RefreshToken        string       `json:"refresh_token,omitempty"`
+	ExpiresIn           int          `json:"expires_in,omitempty"`
+	User                *Colaborador `json:"user,omitempty"`
+	Requer2FA           bool         `json:"requer_2fa,omitempty"`
+	Cadastro2FAPendente bool         `json:"cadastro_2fa_pendente,omitempty"`
+	Desafio             string       `json:"desafio,omitempty"`
+}
+
+// RefreshToken representa um refresh token persistido (apenas o hash é armazenado)
+type RefreshToken struct {
+	ID             int        `json:"id"`
+	ColaboradorID  int        `json:"colaborador_id"`
+	TokenHash      string     `json:"-"`
+	Familia        string     `json:"familia"`
+	ExpiraEm       time.Time  `json:"expira_em"`
+	UsadoEm        *time.Time `json:"usado_em"`
+	RevogadoEm     *time.Time `json:"revogado_em"`
+	SubstituidoPor *int       `json:"substituido_por"`
+	CriadoEm       time.Time  `json:"criado_em"`
+}
+
+// RefreshRequest representa a requisição de renovação do token de acesso
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenRevogado representa um token de acesso revogado antes de expirar
+type TokenRevogado struct {
+	JTI           string    `json:"jti"`
+	ColaboradorID int       `json:"colaborador_id"`
+	ExpiraEm      time.Time `json:"expira_em"`
+	RevogadoEm    time.Time `json:"revogado_em"`
+}
+
+// LogoutRequest representa a requisição de logout
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	Todos        bool   `json:"todos"` // encerra todas as sessões do colaborador
+}
+
+// Sessao representa um login ativo em um dispositivo
+type Sessao struct {
+	ID             int        `json:"id"`
+	ColaboradorID  int        `json:"colaborador_id"`
+	Familia        string     `json:"-"`
+	Dispositivo    string     `json:"dispositivo"`
+	UserAgent      string     `json:"user_agent"`
+	IP             string     `json:"ip"`
+	CriadoEm       time.Time  `json:"criado_em"`
+	UltimoAcessoEm time.Time  `json:"ultimo_acesso_em"`
+	ExpiraEm       time.Time  `json:"expira_em"`
+	EncerradoEm    *time.Time `json:"encerrado_em,omitempty"`
+	Atual          bool       `json:"atual"` // sessão da requisição atual
+}
+
+// TokenRedefinicaoSenha representa um token de uso único para redefinir a senha
+type TokenRedefinicaoSenha struct {
+	ID            int        `json:"id"`
+	ColaboradorID int        `json:"colaborador_id"`
+	TokenHash     string     `json:"-"`
+	ExpiraEm      time.Time  `json:"expira_em"`
+	UsadoEm       *time.Time `json:"usado_em"`
+	CriadoEm      time.Time  `json:"criado_em"`
+}
+
+// EsqueciSenhaRequest representa a solicitação de redefinição de senha
+type EsqueciSenhaRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// RedefinirSenhaRequest representa a redefinição de senha com o token recebido por email
+type RedefinirSenhaRequest struct {
+	Token     string `json:"token" binding:"required"`
+	NovaSenha string `json:"nova_senha" binding:"required"`
+}
+
+// AlterarSenhaRequest representa a troca de senha pelo próprio colaborador
+type AlterarSenhaRequest struct {
+	SenhaAtual string `json:"senha_atual" binding:"required"`
+	NovaSenha  string `json:"nova_senha" binding:"required"`
+}
+
+// ColaboradorAdminRequest representa o cadastro ou a edição de um colaborador
+// pelo RH. A senha é definida pelo próprio colaborador a partir do convite.
+type ColaboradorAdminRequest struct {
+	Nome         string `json:"nome" binding:"required"`
+	Email        string `json:"email" binding:"required,email"`
+	CargoID      int    `json:"cargo_id" binding:"required"`
+	DataAdmissao string `json:"data_admissao"` // AAAA-MM-DD; hoje se vazio no cadastro
+	Telefone     string `json:"telefone"`
+}
+
+// ImportacaoColaboradores é o relatório da importação de uma planilha de
+// colaboradores, linha a linha
+type ImportacaoColaboradores struct {
+	Confirmada bool                          `json:"confirmada"` // false na simulação; nada foi gravado
+	Total      int                           `json:"total"`
+	Validas    int                           `json:"validas"`
+	ComErro    int                           `json:"com_erro"`
+	Linhas     []*ImportacaoColaboradorLinha `json:"linhas"`
+}
+
+// ImportacaoColaboradorLinha é o resultado da validação de uma linha
+type ImportacaoColaboradorLinha struct {
+	Linha         int      `json:"linha"` // número da linha na planilha
+	Nome          string   `json:"nome"`
+	Email         string   `json:"email"`
+	CargoID       int      `json:"cargo_id"`
+	CargoNome     string   `json:"cargo_nome"`
+	DataAdmissao  string   `json:"data_admissao"` // AAAA-MM-DD
+	Telefone      string   `json:"telefone"`
+	ColaboradorID int      `json:"colaborador_id,omitempty"` // preenchido após a confirmação
+	Erros         []string `json:"erros"`
+}
+
+// GestorRequest define ou remove (gestor_id nulo) o gestor direto
+type GestorRequest struct {
+	GestorID *int `json:"gestor_id"`
+}
+
+// DesligamentoRequest representa o desligamento de um colaborador
+type DesligamentoRequest struct {
+	DataDesligamento string `json:"data_desligamento"` // formato: 2006-01-02; vazio: hoje
+	Motivo           string `json:"motivo" binding:"required"`
+	// Recebe os subordinados diretos; nulo: passam ao gestor do desligado
+	SubstitutoID *int `json:"substituto_id"`
+	// Rejeita os documentos pendentes enviados pelo colaborador; por padrão
+	// eles continuam pendentes para o acerto final
+	RejeitarDocumentosPendentes bool   `json:"rejeitar_documentos_pendentes"`
+	PontoDesde                  string `json:"ponto_desde"` // início do espelho de ponto; vazio: primeiro dia do mês do desligamento
+}
+
+// Desligamento é o relatório de um desligamento
+type Desligamento struct {
+	ID               int                          `json:"id"`
+	ColaboradorID    int                          `json:"colaborador_id"`
+	DataDesligamento time.Time                    `json:"data_desligamento"`
+	Motivo           string                       `json:"motivo"`
+	SubstitutoID     *int                         `json:"substituto_id"`
+	RealizadoPor     *int                         `json:"realizado_por"`
+	RealizadoPorNome string                       `json:"realizado_por_nome"`
+	Checklist        []*ItemChecklistDesligamento `json:"checklist"`
+	EspelhoPonto     *EspelhoPonto                `json:"espelho_ponto"`
+	CriadoEm         time.Time                    `json:"criado_em"`
+}
+
+// ItemChecklistDesligamento é uma etapa do desligamento e o seu resultado
+type ItemChecklistDesligamento struct {
+	Item    string `json:"item"`
+	Status  string `json:"status"` // concluido, nao_aplicavel ou falhou
+	Detalhe string `json:"detalhe"`
+}
+
+// EspelhoPonto reúne os registros de ponto de um período e os minutos
+// trabalhados em cada dia
+type EspelhoPonto struct {
+	Inicio       string             `json:"inicio"` // AAAA-MM-DD
+	Fim          string             `json:"fim"`    // AAAA-MM-DD
+	TotalMinutos int                `json:"total_minutos"`
+	Dias         []*EspelhoPontoDia `json:"dias"`
+	Registros    []*RegistroPonto   `json:"registros"`
+}
+
+// EspelhoPontoDia resume um dia do espelho. Inconsistente indica marcações
+// sem par (ex.: entrada sem saída), que não entram no total.
+type EspelhoPontoDia struct {
+	Data               string `json:"data"` // AAAA-MM-DD
+	Registros          int    `json:"registros"`
+	MinutosTrabalhados int    `json:"minutos_trabalhados"`
+	Inconsistente      bool   `json:"inconsistente"`
+}
+
+// ExportacaoDados é um pedido de exportação dos dados pessoais (LGPD). Link
+// é preenchido apenas enquanto o arquivo está disponível.
+type ExportacaoDados struct {
+	ID             int        `json:"id"`
+	ColaboradorID  int        `json:"colaborador_id"`
+	Status         string     `json:"status"`
+	CaminhoArquivo string     `json:"-"`
+	TamanhoBytes   int64      `json:"tamanho_bytes,omitempty"`
+	Erro           string     `json:"erro,omitempty"`
+	Link           string     `json:"link,omitempty"`
+	ExpiraEm       *time.Time `json:"expira_em"`
+	CriadoEm       time.Time  `json:"criado_em"`
+	ConcluidoEm    *time.Time `json:"concluido_em"`
+}
+
+// ResumoAnonimizacao conta o que foi (ou, na simulação, seria) apagado na
+// anonimização de um colaborador
+type ResumoAnonimizacao struct {
+	DadosBancarios   bool `json:"dados_bancarios"`
+	FotoPerfil       bool `json:"foto_perfil"`
+	RegistrosPonto   int  `json:"registros_ponto"` // localização, IP e dispositivo apagados
+	Documentos       int  `json:"documentos"`      // valores e datas mantidos
+	Anexos           int  `json:"anexos"`          // arquivos apagados
+	HistoricoLogin   int  `json:"historico_login"`
+	Sessoes          int  `json:"sessoes"`
+	Desligamentos    int  `json:"desligamentos"` // motivo e localizações do espelho apagados
+	ExportacoesDados int  `json:"exportacoes_dados"`
+}
+
+// ItemRelatorioAnonimizacao é um colaborador no relatório da anonimização.
+// Nome e email aparecem apenas no relatório devolvido, nunca no registro.
+type ItemRelatorioAnonimizacao struct {
+	ColaboradorID    int                 `json:"colaborador_id"`
+	Nome             string              `json:"nome"`
+	Email            string              `json:"email"`
+	DataDesligamento time.Time           `json:"data_desligamento"`
+	Pseudonimo       string              `json:"pseudonimo,omitempty"`
+	Resumo           *ResumoAnonimizacao `json:"resumo"`
+	Erro             string              `json:"erro,omitempty"`
+}
+
+// RelatorioAnonimizacao é o resultado de uma execução da anonimização. Na
+// simulação, lista quem seria anonimizado sem alterar nada.
+type RelatorioAnonimizacao struct {
+	Simulacao     bool                         `json:"simulacao"`
+	DataLimite    string                       `json:"data_limite"` // desligados até esta data (AAAA-MM-DD)
+	Anonimizados  int                          `json:"anonimizados"`
+	Falhas        int                          `json:"falhas"`
+	Colaboradores []*ItemRelatorioAnonimizacao `json:"colaboradores"`
+}
+
+// Anonimizacao é o registro de uma anonimização realizada
+type Anonimizacao struct {
+	ID               int                 `json:"id"`
+	ColaboradorID    *int                `json:"colaborador_id"`
+	Pseudonimo       string              `json:"pseudonimo"`
+	DataDesligamento time.Time           `json:"data_desligamento"`
+	Resumo           *ResumoAnonimizacao `json:"resumo"`
+	RealizadoPor     *int                `json:"realizado_por"` // nulo na rotina agendada
+	RealizadoPorNome string              `json:"realizado_por_nome"`
+	CriadoEm         time.Time           `json:"criado_em"`
+}
+
+// ColaboradorFiltro define a busca e a paginação da listagem de colaboradores
+type ColaboradorFiltro struct {
+	Busca    string // parte do nome ou do email
+	CargoID  int
+	GestorID int // apenas os subordinados diretos deste gestor
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+// DocumentoFiltro define a listagem de documentos. Departamento e centro de
+// custo consideram a alocação do colaborador na data do documento.
+type DocumentoFiltro struct {
+	ColaboradorID  *int
+	Status         string
+	DepartamentoID int
+	CentroCustoID  int
+	Limit          int
+	Offset         int
+}
+
+// PontoFiltro define a listagem de registros de ponto de um dia. Sem
+// colaborador, traz os de todos os alocados no departamento ou centro de
+// custo naquele dia.
+type PontoFiltro struct {
+	ColaboradorID  *int
+	Data           time.Time
+	DepartamentoID int
+	CentroCustoID  int
+}
+
+// ColaboradorLista é uma página da listagem de colaboradores
+type ColaboradorLista struct {
+	Colaboradores []*Colaborador `json:"colaboradores"`
+	Total         int            `json:"total"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+}
+
+// StatusColaboradorRequest representa a alteração de status de um colaborador
+type StatusColaboradorRequest struct {
+	Status string `json:"status" binding:"required"` // ativo, ferias, afastado ou desligado
+}
+
+// Personificacao representa o acesso de um administrador como outro colaborador
+type Personificacao struct {
+	ID          int        `json:"id"`
+	AtorID      int        `json:"ator_id"`
+	AlvoID      int        `json:"alvo_id"`
+	Motivo      string     `json:"motivo"`
+	JTI         string     `json:"-"`
+	IP          string     `json:"ip"`
+	CriadoEm    time.Time  `json:"criado_em"`
+	ExpiraEm    time.Time  `json:"expira_em"`
+	EncerradoEm *time.Time `json:"encerrado_em"`
+}
+
+// RequisicaoPersonificacao representa uma requisição feita durante a personificação
+type RequisicaoPersonificacao struct {
+	ID               int       `json:"id"`
+	PersonificacaoID int       `json:"personificacao_id"`
+	Metodo           string    `json:"metodo"`
+	Rota             string    `json:"rota"`
+	Caminho          string    `json:"caminho"`
+	Status           int       `json:"status"`
+	IP               string    `json:"ip"`
+	CriadoEm         time.Time `json:"criado_em"`
+}
+
+// PersonificacaoRequest representa a solicitação de personificação
+type PersonificacaoRequest struct {
+	Motivo string `json:"motivo" binding:"required"`
+}
+
+// PersonificacaoResponse retorna o token de personificação (sem refresh token)
+type PersonificacaoResponse struct {
+	Token          string          `json:"token"`
+	ExpiresIn      int             `json:"expires_in"`
+	Personificacao *Personificacao `json:"personificacao"`
+}
+
+// AutorizacaoOIDC guarda os dados de um login OIDC entre o redirecionamento
+// para o provedor e o retorno
+type AutorizacaoOIDC struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiraEm     time.Time
+}
+
+// DoisFatores representa a configuração de TOTP de um colaborador
+type DoisFatores struct {
+	ColaboradorID int        `json:"colaborador_id"`
+	Segredo       string     `json:"-"`
+	AtivadoEm     *time.Time `json:"ativado_em"`
+	UltimoPasso   int64      `json:"-"`
+	CriadoEm      time.Time  `json:"criado_em"`
+}
+
+// CodigoRecuperacao representa um código de recuperação de 2FA (hash)
+type CodigoRecuperacao struct {
+	ID            int        `json:"id"`
+	ColaboradorID int        `json:"colaborador_id"`
+	CodigoHash    string     `json:"-"`
+	UsadoEm       *time.Time `json:"usado_em"`
+}
+
+// DoisFatoresCadastroResponse contém o segredo e a URI otpauth:// para o autenticador
+type DoisFatoresCadastroResponse struct {
+	Segredo string `json:"segredo"`
+	URI     string `json:"uri"`
+}
+
+// DoisFatoresAtivacaoResponse retorna os códigos de recuperação (exibidos uma única vez)
+// e, quando a ativação conclui um login, os tokens de acesso
+type DoisFatoresAtivacaoResponse struct {
+	CodigosRecuperacao []string       `json:"codigos_recuperacao"`
+	Login              *LoginResponse `json:"login,omitempty"`
+}
+
+// DesafioRequest identifica um login aguardando o segundo fator
+type DesafioRequest struct {
+	Desafio string `json:"desafio" binding:"required"`
+}
+
+// DoisFatoresVerificarRequest conclui o login com um código TOTP ou de recuperação
+type DoisFatoresVerificarRequest struct {
+	Desafio string `json:"desafio" binding:"required"`
+	Codigo  string `json:"codigo" binding:"required"`
+}
+
+// DoisFatoresCodigoRequest representa um código TOTP informado pelo usuário autenticado
+type DoisFatoresCodigoRequest struct {
+	Codigo string `json:"codigo" binding:"required"`
+}
+
+// TentativaLogin representa o contador de falhas de login de uma conta ou IP
+type TentativaLogin struct {
+	Chave        string     `json:"chave"`
+	Falhas       int        `json:"falhas"`
+	UltimaFalha  time.Time  `json:"ultima_falha"`
+	BloqueadoAte *time.Time `json:"bloqueado_ate"`
+}
+
+// HistoricoLogin representa uma tentativa de login registrada
+type HistoricoLogin struct {
+	ID            int       `json:"id"`
+	ColaboradorID *int      `json:"colaborador_id"`
+	Email         string    `json:"email"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"user_agent"`
+	Resultado     string    `json:"resultado"`
+	CriadoEm      time.Time `json:"criado_em"`
+}
+
+// ContaServico representa uma conta usada por outro sistema (integração)
+type ContaServico struct {
+	ID           int       `json:"id"`
+	Nome         string    `json:"nome" binding:"required"`
+	Descricao    string    `json:"descricao"`
+	Ativo        bool      `json:"ativo"`
+	CriadoPor    *int      `json:"criado_por"`
+	CriadoEm     time.Time `json:"criado_em"`
+	AtualizadoEm time.Time `json:"atualizado_em"`
+}
+
+// ChaveAPI representa uma chave de API de uma conta de serviço (apenas o hash é armazenado)
+type ChaveAPI struct {
+	ID             int        `json:"id"`
+	ContaServicoID int        `json:"conta_servico_id"`
+	Prefixo        string     `json:"prefixo"`
+	SegredoHash    string     `json:"-"`
+	Escopos        []string   `json:"escopos"`
+	ExpiraEm       *time.Time `json:"expira_em"`
+	RevogadoEm     *time.Time `json:"revogado_em"`
+	UltimoUsoEm    *time.Time `json:"ultimo_uso_em"`
+	CriadoPor      *int       `json:"criado_por"`
+	CriadoEm       time.Time  `json:"criado_em"`
+}
+
+// ChaveAPICreateRequest representa a requisição de criação de chave de API
+type ChaveAPICreateRequest struct {
+	Escopos  []string `json:"escopos" binding:"required"`
+	ExpiraEm string   `json:"expira_em"` // formato: 2006-01-02 (opcional)
+}
+
+// ChaveAPICreateResponse retorna a chave completa, exibida uma única vez
+type ChaveAPICreateResponse struct {
+	Chave    string    `json:"chave"`
+	ChaveAPI *ChaveAPI `json:"chave_api"`
 }
 
 // DocumentoCreateRequest representa a requisição para criar documento
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+// vigenteHoje restringe a consulta (alias a) às alocações vigentes hoje
+const vigenteHoje = `AND a.vigencia_inicio <= CURRENT_DATE AND (a.vigencia_fim IS NULL OR a.vigencia_fim >= CURRENT_DATE)`
+
+// AlocacaoRepository mantém a linha do tempo de alocações de cada
+// colaborador: as vigências são contínuas e não se sobrepõem.
+type AlocacaoRepository struct {
+	db *sql.DB
+}
+
+func NewAlocacaoRepository(db *sql.DB) *AlocacaoRepository {
+	return &AlocacaoRepository{db: db}
+}
+
+// ListByColaborador retorna as alocações do colaborador, da mais recente
+// para a mais antiga
+func (r *AlocacaoRepository) ListByColaborador(colaboradorID int) ([]*model.AlocacaoColaborador, error) {
+	query := `
+		SELECT a.id, a.usuario_id, a.departamento_id, COALESCE(d.nome, ''),
+		       a.centro_custo_id, COALESCE(c.nome, ''), a.vigencia_inicio, a.vigencia_fim,
+		       a.criado_por, COALESCE(u.nome, ''), a.criado_em
+		FROM alocacoes_colaborador a
+		LEFT JOIN departamentos d ON a.departamento_id = d.id
+		LEFT JOIN centros_custo c ON a.centro_custo_id = c.id
+		LEFT JOIN usuarios u ON a.criado_por = u.id
+		WHERE a.usuario_id = $1
+		ORDER BY a.vigencia_inicio DESC
+	`
+
+	rows, err := r.db.Query(query, colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alocacoes := []*model.AlocacaoColaborador{}
+	for rows.Next() {
+		alocacao := &model.AlocacaoColaborador{}
+		err := rows.Scan(
+			&alocacao.ID,
+			&alocacao.ColaboradorID,
+			&alocacao.DepartamentoID,
+			&alocacao.DepartamentoNome,
+			&alocacao.CentroCustoID,
+			&alocacao.CentroCustoNome,
+			&alocacao.VigenciaInicio,
+			&alocacao.VigenciaFim,
+			&alocacao.CriadoPor,
+			&alocacao.CriadoPorNome,
+			&alocacao.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alocacoes = append(alocacoes, alocacao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return alocacoes, nil
+}
+
+// Alocar inclui a alocação na linha do tempo do colaborador. Uma alocação
+// que começa no mesmo dia é substituída; a vigente na data passa a terminar
+// no dia anterior; e a nova vale até o início da seguinte, se houver, o que
+// permite registrar mudanças retroativas ou futuras.
+func (r *AlocacaoRepository) Alocar(alocacao *model.AlocacaoColaborador) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := bloquearAlocacoes(tx, alocacao.ColaboradorID); err != nil {
+		return err
+	}
+
+	inicio := alocacao.VigenciaInicio.Format("2006-01-02")
+
+	query := `
+		UPDATE alocacoes_colaborador
+		SET departamento_id = $3, centro_custo_id = $4, criado_por = $5, criado_em = CURRENT_TIMESTAMP
+		WHERE usuario_id = $1 AND vigencia_inicio = $2::date
+		RETURNING id, vigencia_fim, criado_em
+	`
+
+	err = tx.QueryRow(query, alocacao.ColaboradorID, inicio, alocacao.DepartamentoID, alocacao.CentroCustoID, alocacao.CriadoPor).
+		Scan(&alocacao.ID, &alocacao.VigenciaFim, &alocacao.CriadoEm)
+	if err == nil {
+		return tx.Commit()
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE alocacoes_colaborador
+		SET vigencia_fim = $2::date - 1
+		WHERE usuario_id = $1 AND vigencia_inicio < $2::date
+		  AND (vigencia_fim IS NULL OR vigencia_fim >= $2::date)
+	`, alocacao.ColaboradorID, inicio)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO alocacoes_colaborador (
+			usuario_id, departamento_id, centro_custo_id, vigencia_inicio, vigencia_fim, criado_por
+		)
+		SELECT $1, $3, $4, $2::date,
+		       (SELECT MIN(vigencia_inicio) - 1 FROM alocacoes_colaborador
+		        WHERE usuario_id = $1 AND vigencia_inicio > $2::date),
+		       $5
+		RETURNING id, vigencia_fim, criado_em
+	`
+
+	err = tx.QueryRow(query, alocacao.ColaboradorID, inicio, alocacao.DepartamentoID, alocacao.CentroCustoID, alocacao.CriadoPor).
+		Scan(&alocacao.ID, &alocacao.VigenciaFim, &alocacao.CriadoEm)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Remover exclui a alocação; a anterior passa a valer também no período
+// dela. Retorna false se a alocação não existe ou é de outro colaborador.
+func (r *AlocacaoRepository) Remover(colaboradorID, id int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := bloquearAlocacoes(tx, colaboradorID); err != nil {
+		return false, err
+	}
+
+	var inicio time.Time
+	var fim sql.NullTime
+	err = tx.QueryRow(
+		`DELETE FROM alocacoes_colaborador WHERE id = $1 AND usuario_id = $2 RETURNING vigencia_inicio, vigencia_fim`,
+		id, colaboradorID,
+	).Scan(&inicio, &fim)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE alocacoes_colaborador
+		SET vigencia_fim = $3
+		WHERE usuario_id = $1 AND vigencia_fim = $2::date - 1
+	`, colaboradorID, inicio.Format("2006-01-02"), fim)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// bloquearAlocacoes serializa as alterações na linha do tempo do mesmo
+// colaborador até o fim da transação
+func bloquearAlocacoes(tx *sql.Tx, colaboradorID int) error {
+	var id int
+	err := tx.QueryRow(`SELECT id FROM usuarios WHERE id = $1 FOR UPDATE`, colaboradorID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return errors.New("colaborador não encontrado")
+	}
+	return err
+}
+
+// filtroAlocacao monta a condição que mantém apenas os registros cujo
+// colaborador (colunaUsuario) estava alocado no departamento e/ou centro de
+// custo na data do registro (colunaData). IDs zerados não filtram.
+func filtroAlocacao(colunaUsuario, colunaData string, departamentoID, centroCustoID int, params []interface{}) (string, []interface{}) {
+	if departamentoID == 0 && centroCustoID == 0 {
+		return "", params
+	}
+
+	condicao := fmt.Sprintf(`
+		AND EXISTS (
+			SELECT 1 FROM alocacoes_colaborador a
+			WHERE a.usuario_id = %s
+			  AND a.vigencia_inicio <= %s
+			  AND (a.vigencia_fim IS NULL OR a.vigencia_fim >= %s)`,
+		colunaUsuario, colunaData, colunaData)
+
+	if departamentoID != 0 {
+		params = append(params, departamentoID)
+		condicao += fmt.Sprintf(" AND a.departamento_id = $%d", len(params))
+	}
+	if centroCustoID != 0 {
+		params = append(params, centroCustoID)
+		condicao += fmt.Sprintf(" AND a.centro_custo_id = $%d", len(params))
+	}
+
+	return condicao + ")", params
+}
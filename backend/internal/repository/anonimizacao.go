@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+// dataDesligamento é a data do último desligamento do colaborador (alias u).
+// Para desligados antes do registro de desligamentos, usa a última alteração
+// do cadastro, que inclui a mudança de status.
+const dataDesligamento = `COALESCE(
+	(SELECT MAX(d.data_desligamento) FROM desligamentos d WHERE d.usuario_id = u.id),
+	u.atualizado_em::date
+)`
+
+type AnonimizacaoRepository struct {
+	db *sql.DB
+}
+
+func NewAnonimizacaoRepository(db *sql.DB) *AnonimizacaoRepository {
+	return &AnonimizacaoRepository{db: db}
+}
+
+// ListElegiveis retorna os desligados até a data limite que ainda não foram
+// anonimizados, com a contagem do que seria apagado de cada um
+func (r *AnonimizacaoRepository) ListElegiveis(limite time.Time) ([]*model.ItemRelatorioAnonimizacao, error) {
+	query := `
+		SELECT e.id, e.nome, e.email, e.data_desligamento,
+		       e.dados_bancarios IS NOT NULL,
+		       EXISTS (SELECT 1 FROM fotos_perfil f WHERE f.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM registros_ponto p WHERE p.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM recibos r WHERE r.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM recibos r WHERE r.usuario_id = e.id AND COALESCE(r.anexo_url, '') <> ''),
+		       (SELECT COUNT(*) FROM historico_login h WHERE h.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM sessoes s WHERE s.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM desligamentos d WHERE d.usuario_id = e.id),
+		       (SELECT COUNT(*) FROM exportacoes_dados x WHERE x.usuario_id = e.id)
+		FROM (
+			SELECT u.id, u.nome, u.email, u.dados_bancarios, ` + dataDesligamento + ` AS data_desligamento
+			FROM usuarios u
+			WHERE u.status = 'desligado' AND u.anonimizado_em IS NULL
+		) e
+		WHERE e.data_desligamento <= $1::date
+		ORDER BY e.data_desligamento, e.id
+	`
+
+	rows, err := r.db.Query(query, limite.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itens := []*model.ItemRelatorioAnonimizacao{}
+	for rows.Next() {
+		item := &model.ItemRelatorioAnonimizacao{Resumo: &model.ResumoAnonimizacao{}}
+		err := rows.Scan(
+			&item.ColaboradorID,
+			&item.Nome,
+			&item.Email,
+			&item.DataDesligamento,
+			&item.Resumo.DadosBancarios,
+			&item.Resumo.FotoPerfil,
+			&item.Resumo.RegistrosPonto,
+			&item.Resumo.Documentos,
+			&item.Resumo.Anexos,
+			&item.Resumo.HistoricoLogin,
+			&item.Resumo.Sessoes,
+			&item.Resumo.Desligamentos,
+			&item.Resumo.ExportacoesDados,
+		)
+		if err != nil {
+			return nil, err
+		}
+		itens = append(itens, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return itens, nil
+}
+
+// Anonimizar apaga, em uma única transação, os dados pessoais do colaborador:
+// nome, email, telefone e uuid passam ao pseudônimo; senha, foto e dados
+// bancários são apagados; os registros de ponto perdem localização, IP,
+// dispositivo e observação; os recibos perdem descrição e anexo, mantendo
+// valores e datas; o histórico de alterações perde os valores de nome, email
+// e telefone; e o histórico de login, as sessões, os tokens, o 2FA e as
+// exportações são excluídos. Os desligamentos perdem o motivo e os dados de
+// localização do espelho. Grava o registro da anonimização, com o resumo em
+// a.Resumo, e retorna os arquivos a apagar do armazenamento depois da
+// confirmação. Retorna false se o colaborador deixou de ser elegível (não
+// está desligado, já foi anonimizado ou foi desligado depois do limite).
+func (r *AnonimizacaoRepository) Anonimizar(a *model.Anonimizacao, nome, email, uuid string, limite time.Time) ([]string, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	var emailAnterior string
+	var cifrados []byte
+	err = tx.QueryRow(`
+		SELECT u.email, u.dados_bancarios, `+dataDesligamento+`
+		FROM usuarios u
+		WHERE u.id = $1 AND u.status = 'desligado' AND u.anonimizado_em IS NULL
+		FOR UPDATE
+	`, *a.ColaboradorID).Scan(&emailAnterior, &cifrados, &a.DataDesligamento)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if a.DataDesligamento.After(limite) {
+		return nil, false, nil
+	}
+
+	id := *a.ColaboradorID
+	resumo := &model.ResumoAnonimizacao{DadosBancarios: cifrados != nil}
+	arquivos := []string{}
+
+	antes, err := dadosColaborador(tx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE usuarios
+		SET uuid = $2, nome = $3, email = $4, senha = '', telefone = '', foto_perfil = '',
+		    dados_bancarios = NULL, anonimizado_em = CURRENT_TIMESTAMP, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id, uuid, nome, email)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// A própria anonimização entra no histórico antes de os valores pessoais
+	// serem apagados de todos os registros dele
+	depois, err := dadosColaborador(tx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	realizadoPor := 0
+	if a.RealizadoPor != nil {
+		realizadoPor = *a.RealizadoPor
+	}
+	if err := registrarHistoricoColaborador(tx, id, AcaoColaboradorAnonimizado, realizadoPor, antes, depois); err != nil {
+		return nil, false, err
+	}
+	if err := anonimizarHistoricoColaborador(tx, id, nome); err != nil {
+		return nil, false, err
+	}
+
+	var grande, pequeno string
+	err = tx.QueryRow(`DELETE FROM fotos_perfil WHERE usuario_id = $1 RETURNING caminho_grande, caminho_pequeno`, id).
+		Scan(&grande, &pequeno)
+	switch {
+	case err == nil:
+		resumo.FotoPerfil = true
+		arquivos = append(arquivos, grande, pequeno)
+	case err != sql.ErrNoRows:
+		return nil, false, err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE registros_ponto
+		SET localizacao = NULL, ip_origem = '', dispositivo = '', observacao = ''
+		WHERE usuario_id = $1
+	`, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if resumo.RegistrosPonto, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	// Os anexos são lidos antes de serem desvinculados, para apagar os arquivos
+	anexos, err := tx.Query(`SELECT anexo_url FROM recibos WHERE usuario_id = $1 AND COALESCE(anexo_url, '') <> ''`, id)
+	if err != nil {
+		return nil, false, err
+	}
+	for anexos.Next() {
+		var caminho string
+		if err := anexos.Scan(&caminho); err != nil {
+			anexos.Close()
+			return nil, false, err
+		}
+		arquivos = append(arquivos, caminho)
+		resumo.Anexos++
+	}
+	anexos.Close()
+	if err := anexos.Err(); err != nil {
+		return nil, false, err
+	}
+
+	result, err = tx.Exec(`
+		UPDATE recibos
+		SET descricao = '', anexo_url = '', atualizado_em = CURRENT_TIMESTAMP
+		WHERE usuario_id = $1
+	`, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if resumo.Documentos, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	result, err = tx.Exec(`DELETE FROM historico_login WHERE usuario_id = $1 OR email = $2`, id, emailAnterior)
+	if err != nil {
+		return nil, false, err
+	}
+	if resumo.HistoricoLogin, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	result, err = tx.Exec(`DELETE FROM sessoes WHERE usuario_id = $1`, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if resumo.Sessoes, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	for _, query := range []string{
+		`DELETE FROM refresh_tokens WHERE usuario_id = $1`,
+		`DELETE FROM tokens_redefinicao_senha WHERE usuario_id = $1`,
+		`DELETE FROM codigos_recuperacao WHERE usuario_id = $1`,
+		`DELETE FROM dois_fatores WHERE usuario_id = $1`,
+		`DELETE FROM historico_senhas WHERE usuario_id = $1`,
+	} {
+		if _, err := tx.Exec(query, id); err != nil {
+			return nil, false, err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM tentativas_login WHERE chave = $1`, "conta:"+emailAnterior); err != nil {
+		return nil, false, err
+	}
+
+	rows, err := tx.Query(`DELETE FROM exportacoes_dados WHERE usuario_id = $1 RETURNING COALESCE(caminho_arquivo, '')`, id)
+	if err != nil {
+		return nil, false, err
+	}
+	for rows.Next() {
+		var caminho string
+		if err := rows.Scan(&caminho); err != nil {
+			rows.Close()
+			return nil, false, err
+		}
+		resumo.ExportacoesDados++
+		if caminho != "" {
+			arquivos = append(arquivos, caminho)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if resumo.Desligamentos, err = anonimizarDesligamentos(tx, id); err != nil {
+		return nil, false, err
+	}
+
+	resumoJSON, err := json.Marshal(resumo)
+	if err != nil {
+		return nil, false, err
+	}
+	err = tx.QueryRow(`
+		INSERT INTO anonimizacoes (usuario_id, pseudonimo, data_desligamento, resumo, realizado_por)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, criado_em
+	`, id, a.Pseudonimo, a.DataDesligamento, resumoJSON, a.RealizadoPor).Scan(&a.ID, &a.CriadoEm)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	a.Resumo = resumo
+	return arquivos, true, nil
+}
+
+// List retorna as anonimizações realizadas, da mais recente para a mais antiga
+func (r *AnonimizacaoRepository) List(limit, offset int) ([]*model.Anonimizacao, error) {
+	query := `
+		SELECT a.id, a.usuario_id, a.pseudonimo, a.data_desligamento, a.resumo,
+		       a.realizado_por, COALESCE(u.nome, ''), a.criado_em
+		FROM anonimizacoes a
+		LEFT JOIN usuarios u ON a.realizado_por = u.id
+		ORDER BY a.criado_em DESC, a.id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	anonimizacoes := []*model.Anonimizacao{}
+	for rows.Next() {
+		a := &model.Anonimizacao{}
+		var resumo []byte
+
+		err := rows.Scan(
+			&a.ID,
+			&a.ColaboradorID,
+			&a.Pseudonimo,
+			&a.DataDesligamento,
+			&resumo,
+			&a.RealizadoPor,
+			&a.RealizadoPorNome,
+			&a.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(resumo, &a.Resumo); err != nil {
+			return nil, err
+		}
+
+		anonimizacoes = append(anonimizacoes, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return anonimizacoes, nil
+}
+
+// anonimizarDesligamentos apaga o motivo dos desligamentos do colaborador e
+// os dados de localização dos registros guardados no espelho de ponto,
+// mantendo as datas e os minutos trabalhados
+func anonimizarDesligamentos(tx *sql.Tx, colaboradorID int) (int, error) {
+	rows, err := tx.Query(`SELECT id, espelho_ponto FROM desligamentos WHERE usuario_id = $1`, colaboradorID)
+	if err != nil {
+		return 0, err
+	}
+
+	espelhos := map[int]*model.EspelhoPonto{}
+	for rows.Next() {
+		var id int
+		var espelho []byte
+		if err := rows.Scan(&id, &espelho); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		var espelhoPonto *model.EspelhoPonto
+		if espelho != nil {
+			if err := json.Unmarshal(espelho, &espelhoPonto); err != nil {
+				rows.Close()
+				return 0, err
+			}
+		}
+		espelhos[id] = espelhoPonto
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for id, espelho := range espelhos {
+		var espelhoJSON []byte
+		if espelho != nil {
+			for _, registro := range espelho.Registros {
+				registro.Localizacao = nil
+				registro.IPOrigem = ""
+				registro.Dispositivo = ""
+				registro.Observacao = ""
+			}
+			if espelhoJSON, err = json.Marshal(espelho); err != nil {
+				return 0, err
+			}
+		}
+
+		_, err := tx.Exec(`UPDATE desligamentos SET motivo = '', espelho_ponto = $2 WHERE id = $1`, id, espelhoJSON)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(espelhos), nil
+}
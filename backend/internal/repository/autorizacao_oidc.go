@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type AutorizacaoOIDCRepository struct {
+	db *sql.DB
+}
+
+func NewAutorizacaoOIDCRepository(db *sql.DB) *AutorizacaoOIDCRepository {
+	return &AutorizacaoOIDCRepository{db: db}
+}
+
+func (r *AutorizacaoOIDCRepository) Create(autorizacao *model.AutorizacaoOIDC) error {
+	query := `
+		INSERT INTO autorizacoes_oidc (state, nonce, code_verifier, expira_em)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Exec(query, autorizacao.State, autorizacao.Nonce, autorizacao.CodeVerifier, autorizacao.ExpiraEm)
+	return err
+}
+
+// Consume remove e retorna a autorização do state de forma atômica, para que
+// o mesmo retorno do provedor não possa ser usado duas vezes
+func (r *AutorizacaoOIDCRepository) Consume(state string, now time.Time) (*model.AutorizacaoOIDC, error) {
+	query := `
+		DELETE FROM autorizacoes_oidc
+		WHERE state = $1 AND expira_em > $2
+		RETURNING state, nonce, code_verifier, expira_em
+	`
+
+	autorizacao := &model.AutorizacaoOIDC{}
+	err := r.db.QueryRow(query, state, now).Scan(
+		&autorizacao.State,
+		&autorizacao.Nonce,
+		&autorizacao.CodeVerifier,
+		&autorizacao.ExpiraEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("autorização não encontrada")
+		}
+		return nil, err
+	}
+
+	return autorizacao, nil
+}
+
+// DeleteExpired remove autorizações abandonadas antes do retorno do provedor
+func (r *AutorizacaoOIDCRepository) DeleteExpired(now time.Time) error {
+	_, err := r.db.Exec(`DELETE FROM autorizacoes_oidc WHERE expira_em <= $1`, now)
+	return err
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"empresa-app/backend/internal/model"
+)
+
+type CargoRepository struct {
+	db *sql.DB
+}
+
+func NewCargoRepository(db *sql.DB) *CargoRepository {
+	return &CargoRepository{db: db}
+}
+
+func (r *CargoRepository) GetByID(id int) (*model.Cargo, error) {
+	query := `
+		SELECT id, nome, descricao, exige_2fa, criado_em, atualizado_em
+		FROM cargos
+		WHERE id = $1
+	`
+
+	cargo := &model.Cargo{}
+	var descricao sql.NullString
+
+	err := r.db.QueryRow(query, id).Scan(
+		&cargo.ID,
+		&cargo.Nome,
+		&descricao,
+		&cargo.Exige2FA,
+		&cargo.CriadoEm,
+		&cargo.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("cargo não encontrado")
+		}
+		return nil, err
+	}
+
+	cargo.Descricao = descricao.String
+
+	return cargo, nil
+}
+
+// Ações registradas no histórico de cargos
+const (
+	AcaoCargoCriado             = "criado"
+	AcaoCargoAtualizado         = "atualizado"
+	AcaoCargoExcluido           = "excluido"
+	AcaoCargoPermissaoConcedida = "permissao_concedida"
+	AcaoCargoPermissaoRemovida  = "permissao_removida"
+)
+
+// execer é satisfeito por *sql.DB e *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// List retorna todos os cargos com a quantidade de colaboradores de cada um
+func (r *CargoRepository) List() ([]*model.Cargo, error) {
+	query := `
+		SELECT c.id, c.nome, c.descricao, c.exige_2fa, c.criado_em, c.atualizado_em,
+		       (SELECT COUNT(*) FROM usuarios u WHERE u.cargo_id = c.id)
+		FROM cargos c
+		ORDER BY c.nome
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cargos := []*model.Cargo{}
+	for rows.Next() {
+		cargo := &model.Cargo{}
+		var descricao sql.NullString
+
+		err := rows.Scan(
+			&cargo.ID,
+			&cargo.Nome,
+			&descricao,
+			&cargo.Exige2FA,
+			&cargo.CriadoEm,
+			&cargo.AtualizadoEm,
+			&cargo.TotalColaboradores,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cargo.Descricao = descricao.String
+		cargos = append(cargos, cargo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cargos, nil
+}
+
+// ExistsNome informa se outro cargo já usa o nome (sem diferenciar maiúsculas)
+func (r *CargoRepository) ExistsNome(nome string, ignorarID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM cargos WHERE LOWER(nome) = LOWER($1) AND id <> $2)`,
+		nome, ignorarID,
+	).Scan(&exists)
+	return exists, err
+}
+
+// CountColaboradores retorna quantos colaboradores estão no cargo
+func (r *CargoRepository) CountColaboradores(id int) (int, error) {
+	var total int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM usuarios WHERE cargo_id = $1`, id).Scan(&total)
+	return total, err
+}
+
+// Create cadastra o cargo e registra o histórico na mesma transação
+func (r *CargoRepository) Create(cargo *model.Cargo, alteradoPor int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO cargos (nome, descricao, exige_2fa)
+		VALUES ($1, $2, $3)
+		RETURNING id, criado_em, atualizado_em
+	`
+
+	err = tx.QueryRow(query, cargo.Nome, cargo.Descricao, cargo.Exige2FA).
+		Scan(&cargo.ID, &cargo.CriadoEm, &cargo.AtualizadoEm)
+	if err != nil {
+		return err
+	}
+
+	if err := registrarHistoricoCargo(tx, cargo.ID, AcaoCargoCriado, alteradoPor, nil, dadosCargo(cargo)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update altera o cargo e registra os dados anteriores e os novos no histórico
+func (r *CargoRepository) Update(cargo, anterior *model.Cargo, alteradoPor int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE cargos
+		SET nome = $1, descricao = $2, exige_2fa = $3, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING atualizado_em
+	`
+
+	err = tx.QueryRow(query, cargo.Nome, cargo.Descricao, cargo.Exige2FA, cargo.ID).Scan(&cargo.AtualizadoEm)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("cargo não encontrado")
+		}
+		return err
+	}
+
+	if err := registrarHistoricoCargo(tx, cargo.ID, AcaoCargoAtualizado, alteradoPor, dadosCargo(anterior), dadosCargo(cargo)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete exclui o cargo se nenhum colaborador estiver nele. Retorna false se
+// o cargo não existe ou ainda tem colaboradores. As permissões do cargo são
+// removidas antes, e não pela exclusão em cascata, para que cada remoção
+// fique no histórico.
+func (r *CargoRepository) Delete(cargo *model.Cargo, alteradoPor int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Travar o cargo impede concessões simultâneas à exclusão
+	var id int
+	err = tx.QueryRow(`SELECT id FROM cargos WHERE id = $1 FOR UPDATE`, cargo.ID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	permissoes, err := removerPermissoesCargo(tx, cargo.ID)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		DELETE FROM cargos
+		WHERE id = $1
+		  AND NOT EXISTS (SELECT 1 FROM usuarios WHERE cargo_id = $1)
+	`
+
+	result, err := tx.Exec(query, cargo.ID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	for _, permissao := range permissoes {
+		dados := map[string]interface{}{"permissao": permissao}
+		if err := registrarHistoricoCargo(tx, cargo.ID, AcaoCargoPermissaoRemovida, alteradoPor, dados, nil); err != nil {
+			return false, err
+		}
+	}
+
+	if err := registrarHistoricoCargo(tx, cargo.ID, AcaoCargoExcluido, alteradoPor, dadosCargo(cargo), nil); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// removerPermissoesCargo remove todas as permissões do cargo e retorna os
+// códigos removidos
+func removerPermissoesCargo(tx *sql.Tx, cargoID int) ([]string, error) {
+	rows, err := tx.Query(`
+		DELETE FROM cargo_permissoes cp
+		USING permissoes p
+		WHERE cp.permissao_id = p.id AND cp.cargo_id = $1
+		RETURNING p.codigo
+	`, cargoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissoes := []string{}
+	for rows.Next() {
+		var codigo string
+		if err := rows.Scan(&codigo); err != nil {
+			return nil, err
+		}
+		permissoes = append(permissoes, codigo)
+	}
+
+	return permissoes, rows.Err()
+}
+
+// RegistrarHistorico grava uma alteração feita fora deste repositório, como a
+// concessão de permissões
+func (r *CargoRepository) RegistrarHistorico(cargoID int, acao string, alteradoPor int, anteriores, novos interface{}) error {
+	return registrarHistoricoCargo(r.db, cargoID, acao, alteradoPor, anteriores, novos)
+}
+
+// ListHistorico retorna as alterações do cargo, da mais recente para a mais antiga
+func (r *CargoRepository) ListHistorico(cargoID int) ([]*model.HistoricoCargo, error) {
+	query := `
+		SELECT h.id, h.cargo_id, h.acao, h.alterado_por, COALESCE(u.nome, ''),
+		       h.dados_anteriores, h.dados_novos, h.criado_em
+		FROM historico_cargos h
+		LEFT JOIN usuarios u ON h.alterado_por = u.id
+		WHERE h.cargo_id = $1
+		ORDER BY h.criado_em DESC, h.id DESC
+	`
+
+	rows, err := r.db.Query(query, cargoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	historico := []*model.HistoricoCargo{}
+	for rows.Next() {
+		item := &model.HistoricoCargo{}
+		var alteradoPor sql.NullInt64
+		var anteriores, novos []byte
+
+		err := rows.Scan(
+			&item.ID,
+			&item.CargoID,
+			&item.Acao,
+			&alteradoPor,
+			&item.AlteradoPorNome,
+			&anteriores,
+			&novos,
+			&item.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if alteradoPor.Valid {
+			id := int(alteradoPor.Int64)
+			item.AlteradoPor = &id
+		}
+		if anteriores != nil {
+			item.DadosAnteriores = anteriores
+		}
+		if novos != nil {
+			item.DadosNovos = novos
+		}
+
+		historico = append(historico, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return historico, nil
+}
+
+func registrarHistoricoCargo(db execer, cargoID int, acao string, alteradoPor int, anteriores, novos interface{}) error {
+	dadosAnteriores, err := jsonOuNulo(anteriores)
+	if err != nil {
+		return err
+	}
+	dadosNovos, err := jsonOuNulo(novos)
+	if err != nil {
+		return err
+	}
+
+	var autor interface{}
+	if alteradoPor > 0 {
+		autor = alteradoPor
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO historico_cargos (cargo_id, acao, alterado_por, dados_anteriores, dados_novos)
+		VALUES ($1, $2, $3, $4, $5)
+	`, cargoID, acao, autor, dadosAnteriores, dadosNovos)
+	return err
+}
+
+// dadosCargo são os campos do cargo guardados no histórico
+func dadosCargo(cargo *model.Cargo) map[string]interface{} {
+	return map[string]interface{}{
+		"nome":      cargo.Nome,
+		"descricao": cargo.Descricao,
+		"exige_2fa": cargo.Exige2FA,
+	}
+}
+
+func jsonOuNulo(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"empresa-app/backend/internal/model"
+)
+
+type CentroCustoRepository struct {
+	db *sql.DB
+}
+
+func NewCentroCustoRepository(db *sql.DB) *CentroCustoRepository {
+	return &CentroCustoRepository{db: db}
+}
+
+func (r *CentroCustoRepository) GetByID(id int) (*model.CentroCusto, error) {
+	query := `
+		SELECT id, codigo, nome, ativo, criado_em, atualizado_em
+		FROM centros_custo
+		WHERE id = $1
+	`
+
+	centro := &model.CentroCusto{}
+	err := r.db.QueryRow(query, id).Scan(
+		&centro.ID,
+		&centro.Codigo,
+		&centro.Nome,
+		&centro.Ativo,
+		&centro.CriadoEm,
+		&centro.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("centro de custo não encontrado")
+		}
+		return nil, err
+	}
+
+	return centro, nil
+}
+
+// List retorna os centros de custo com a quantidade de colaboradores
+// alocados hoje em cada um
+func (r *CentroCustoRepository) List(incluirInativos bool) ([]*model.CentroCusto, error) {
+	query := `
+		SELECT c.id, c.codigo, c.nome, c.ativo, c.criado_em, c.atualizado_em,
+		       (SELECT COUNT(*) FROM alocacoes_colaborador a
+		        WHERE a.centro_custo_id = c.id ` + vigenteHoje + `)
+		FROM centros_custo c
+		WHERE c.ativo OR $1
+		ORDER BY c.codigo
+	`
+
+	rows, err := r.db.Query(query, incluirInativos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	centros := []*model.CentroCusto{}
+	for rows.Next() {
+		centro := &model.CentroCusto{}
+		err := rows.Scan(
+			&centro.ID,
+			&centro.Codigo,
+			&centro.Nome,
+			&centro.Ativo,
+			&centro.CriadoEm,
+			&centro.AtualizadoEm,
+			&centro.TotalColaboradores,
+		)
+		if err != nil {
+			return nil, err
+		}
+		centros = append(centros, centro)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return centros, nil
+}
+
+// ExistsCodigo informa se outro centro de custo já usa o código (sem
+// diferenciar maiúsculas)
+func (r *CentroCustoRepository) ExistsCodigo(codigo string, ignorarID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM centros_custo WHERE LOWER(codigo) = LOWER($1) AND id <> $2)`,
+		codigo, ignorarID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *CentroCustoRepository) Create(centro *model.CentroCusto) error {
+	query := `
+		INSERT INTO centros_custo (codigo, nome, ativo)
+		VALUES ($1, $2, $3)
+		RETURNING id, criado_em, atualizado_em
+	`
+
+	return r.db.QueryRow(query, centro.Codigo, centro.Nome, centro.Ativo).
+		Scan(&centro.ID, &centro.CriadoEm, &centro.AtualizadoEm)
+}
+
+func (r *CentroCustoRepository) Update(centro *model.CentroCusto) error {
+	query := `
+		UPDATE centros_custo
+		SET codigo = $1, nome = $2, ativo = $3, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING atualizado_em
+	`
+
+	err := r.db.QueryRow(query, centro.Codigo, centro.Nome, centro.Ativo, centro.ID).
+		Scan(&centro.AtualizadoEm)
+	if err == sql.ErrNoRows {
+		return errors.New("centro de custo não encontrado")
+	}
+	return err
+}
+
+// Delete exclui o centro de custo se ele nunca teve colaboradores alocados,
+// preservando o histórico. Retorna false se o centro de custo não existe ou
+// já foi usado.
+func (r *CentroCustoRepository) Delete(id int) (bool, error) {
+	query := `
+		DELETE FROM centros_custo
+		WHERE id = $1
+		  AND NOT EXISTS (SELECT 1 FROM alocacoes_colaborador WHERE centro_custo_id = $1)
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
@@ -3,26 +3,33 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/lib/pq"
 
 	"empresa-app/backend/internal/model"
+	"empresa-app/backend/pkg/passhash"
 )
 
 type ColaboradorRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	hasher *passhash.Hasher
 }
 
-func NewColaboradorRepository(db *sql.DB) *ColaboradorRepository {
-	return &ColaboradorRepository{db: db}
+func NewColaboradorRepository(db *sql.DB, hasher *passhash.Hasher) *ColaboradorRepository {
+	return &ColaboradorRepository{db: db, hasher: hasher}
 }
 
 func (r *ColaboradorRepository) GetByID(id int) (*model.Colaborador, error) {
 	query := `
-		SELECT id, uuid, nome, email, cargo_id, data_admissao, status, 
-		       foto_perfil, telefone, dados_bancarios, criado_em, atualizado_em
-		FROM usuarios
-		WHERE id = $1
+		SELECT u.id, u.uuid, u.nome, u.email, u.cargo_id, COALESCE(c.nome, ''),
+		       u.gestor_id, COALESCE(g.nome, ''), u.data_admissao, u.status,
+		       u.foto_perfil, u.telefone, u.dados_bancarios, u.criado_em, u.atualizado_em
+		FROM usuarios u
+		LEFT JOIN cargos c ON u.cargo_id = c.id
+		LEFT JOIN usuarios g ON u.gestor_id = g.id
+		WHERE u.id = $1
 	`
 
 	colaborador := &model.Colaborador{}
@@ -32,11 +39,14 @@ func (r *ColaboradorRepository) GetByID(id int) (*model.Colaborador, error) {
 		&colaborador.Nome,
 		&colaborador.Email,
 		&colaborador.CargoID,
+		&colaborador.CargoNome,
+		&colaborador.GestorID,
+		&colaborador.GestorNome,
 		&colaborador.DataAdmissao,
 		&colaborador.Status,
 		&colaborador.FotoPerfil,
 		&colaborador.Telefone,
-		&colaborador.DadosBancarios,
+		&colaborador.DadosBancariosCifrados,
 		&colaborador.CriadoEm,
 		&colaborador.AtualizadoEm,
 	)
@@ -52,12 +62,42 @@ func (r *ColaboradorRepository) GetByID(id int) (*model.Colaborador, error) {
 }
 
 func (r *ColaboradorRepository) GetByEmail(email string) (*model.Colaborador, error) {
+	return r.getByEmail("u.email = $1", email)
+}
+
+// GetByEmailIgnoreCase busca o colaborador pelo email sem diferenciar
+// maiúsculas de minúsculas. Se mais de um cadastro corresponder, nenhum é
+// retornado e o erro é ErrEmailAmbiguo.
+func (r *ColaboradorRepository) GetByEmailIgnoreCase(email string) (*model.Colaborador, error) {
+	colaborador, err := r.getByEmail(`LOWER(u.email) = LOWER($1)
+		  AND (SELECT COUNT(*) FROM usuarios o WHERE LOWER(o.email) = LOWER($1)) = 1`, email)
+	if !errors.Is(err, ErrColaboradorNaoEncontrado) {
+		return colaborador, err
+	}
+
+	// Sem resultado: nenhum cadastro ou mais de um com o mesmo email. Um
+	// cadastro que tenha surgido entre as duas consultas também é tratado como
+	// ambíguo, para que o chamador não crie outro.
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM usuarios WHERE LOWER(email) = LOWER($1)`, email).Scan(&total); err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return nil, ErrEmailAmbiguo
+	}
+
+	return nil, ErrColaboradorNaoEncontrado
+}
+
+func (r *ColaboradorRepository) getByEmail(where, email string) (*model.Colaborador, error) {
 	query := `
-		SELECT id, uuid, nome, email, senha, cargo_id, data_admissao, status, 
-		       foto_perfil, telefone, dados_bancarios, criado_em, atualizado_em
-		FROM usuarios
-		WHERE email = $1
-	`
+		SELECT u.id, u.uuid, u.nome, u.email, u.senha, u.cargo_id, COALESCE(c.nome, ''),
+		       u.gestor_id, COALESCE(g.nome, ''), u.data_admissao, u.status,
+		       u.foto_perfil, u.telefone, u.dados_bancarios, u.criado_em, u.atualizado_em
+		FROM usuarios u
+		LEFT JOIN cargos c ON u.cargo_id = c.id
+		LEFT JOIN usuarios g ON u.gestor_id = g.id
+		WHERE ` + where
 
 	colaborador := &model.Colaborador{}
 	err := r.db.QueryRow(query, email).Scan(
@@ -67,18 +107,21 @@ func (r *ColaboradorRepository) GetByEmail(email string) (*model.Colaborador, er
 		&colaborador.Email,
 		&colaborador.Senha,
 		&colaborador.CargoID,
+		&colaborador.CargoNome,
+		&colaborador.GestorID,
+		&colaborador.GestorNome,
 		&colaborador.DataAdmissao,
 		&colaborador.Status,
 		&colaborador.FotoPerfil,
 		&colaborador.Telefone,
-		&colaborador.DadosBancarios,
+		&colaborador.DadosBancariosCifrados,
 		&colaborador.CriadoEm,
 		&colaborador.AtualizadoEm,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("colaborador não encontrado")
+			return nil, ErrColaboradorNaoEncontrado
 		}
 		return nil, err
 	}
@@ -86,32 +129,119 @@ func (r *ColaboradorRepository) GetByEmail(email string) (*model.Colaborador, er
 	return colaborador, nil
 }
 
-func (r *ColaboradorRepository) Create(colaborador *model.Colaborador) error {
+// Create cadastra o colaborador e registra o cadastro no histórico.
+// alteradoPor zero indica um cadastro automático.
+func (r *ColaboradorRepository) Create(colaborador *model.Colaborador, alteradoPor int) error {
 	// Hash da senha
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(colaborador.Senha), bcrypt.DefaultCost)
+	hashedPassword, err := r.hasher.Hash(colaborador.Senha)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertColaborador(tx, colaborador, hashedPassword, alteradoPor); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EmailDuplicadoLoteError indica qual colaborador do lote tem um email já
+// cadastrado
+type EmailDuplicadoLoteError struct {
+	Indice int
+}
+
+func (e *EmailDuplicadoLoteError) Error() string {
+	return fmt.Sprintf("colaborador %d do lote: %v", e.Indice, ErrEmailDuplicado)
+}
+
+func (e *EmailDuplicadoLoteError) Unwrap() error {
+	return ErrEmailDuplicado
+}
+
+// CreateMany cadastra todos os colaboradores em uma única transação: se um
+// falhar, nenhum é gravado. Um email já cadastrado é informado com
+// *EmailDuplicadoLoteError.
+func (r *ColaboradorRepository) CreateMany(colaboradores []*model.Colaborador, alteradoPor int) error {
+	// Os hashes são calculados antes, para não manter a transação aberta
+	hashes := make([]string, len(colaboradores))
+	for i, colaborador := range colaboradores {
+		hashedPassword, err := r.hasher.Hash(colaborador.Senha)
+		if err != nil {
+			return err
+		}
+		hashes[i] = hashedPassword
+	}
+
+	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
+
+	for i, colaborador := range colaboradores {
+		if err := insertColaborador(tx, colaborador, hashes[i], alteradoPor); err != nil {
+			if errors.Is(err, ErrEmailDuplicado) {
+				return &EmailDuplicadoLoteError{Indice: i}
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// queryRower é satisfeito por *sql.DB e *sql.Tx
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// ErrEmailDuplicado indica que o email já é usado por outro colaborador,
+// detectado pela restrição de unicidade (cadastros simultâneos)
+var ErrEmailDuplicado = errors.New("email já cadastrado")
+
+// ErrColaboradorNaoEncontrado indica que nenhum colaborador corresponde ao ID
+// ou ao email buscado
+var ErrColaboradorNaoEncontrado = errors.New("colaborador não encontrado")
+
+// ErrEmailAmbiguo indica que mais de um colaborador usa o email quando a
+// capitalização é ignorada
+var ErrEmailAmbiguo = errors.New("mais de um colaborador cadastrado com este email")
+
+// erroEmailDuplicado converte a violação da unicidade do email em ErrEmailDuplicado
+func erroEmailDuplicado(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && strings.Contains(pqErr.Constraint, "email") {
+		return ErrEmailDuplicado
+	}
+	return err
+}
 
+func insertColaborador(tx *sql.Tx, colaborador *model.Colaborador, hashedPassword string, alteradoPor int) error {
 	query := `
 		INSERT INTO usuarios (
 			nome, email, senha, cargo_id, data_admissao, status,
-			foto_perfil, telefone, dados_bancarios
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			foto_perfil, telefone
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, uuid, criado_em, atualizado_em
 	`
 
-	err = r.db.QueryRow(
+	err := tx.QueryRow(
 		query,
 		colaborador.Nome,
 		colaborador.Email,
-		string(hashedPassword),
+		hashedPassword,
 		colaborador.CargoID,
 		colaborador.DataAdmissao,
 		colaborador.Status,
 		colaborador.FotoPerfil,
 		colaborador.Telefone,
-		colaborador.DadosBancarios,
 	).Scan(
 		&colaborador.ID,
 		&colaborador.UUID,
@@ -119,52 +249,355 @@ func (r *ColaboradorRepository) Create(colaborador *model.Colaborador) error {
 		&colaborador.AtualizadoEm,
 	)
 
+	if err != nil {
+		return erroEmailDuplicado(err)
+	}
+
+	dados, err := dadosColaborador(tx, colaborador.ID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return registrarHistoricoColaborador(tx, colaborador.ID, AcaoColaboradorCriado, alteradoPor, nil, dados)
 }
 
-func (r *ColaboradorRepository) Update(colaborador *model.Colaborador) error {
+func (r *ColaboradorRepository) Update(colaborador *model.Colaborador, alteradoPor int) error {
 	query := `
 		UPDATE usuarios
 		SET nome = $1, cargo_id = $2, status = $3, foto_perfil = $4, 
-		    telefone = $5, dados_bancarios = $6, atualizado_em = CURRENT_TIMESTAMP
-		WHERE id = $7
+		    telefone = $5, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $6
 		RETURNING atualizado_em
 	`
 
-	err := r.db.QueryRow(
-		query,
-		colaborador.Nome,
-		colaborador.CargoID,
-		colaborador.Status,
-		colaborador.FotoPerfil,
-		colaborador.Telefone,
-		colaborador.DadosBancarios,
-		colaborador.ID,
-	).Scan(&colaborador.AtualizadoEm)
+	return r.alterarComHistorico(colaborador.ID, alteradoPor, func(tx *sql.Tx) error {
+		return tx.QueryRow(
+			query,
+			colaborador.Nome,
+			colaborador.CargoID,
+			colaborador.Status,
+			colaborador.FotoPerfil,
+			colaborador.Telefone,
+			colaborador.ID,
+		).Scan(&colaborador.AtualizadoEm)
+	})
+}
+
+// List retorna uma página de colaboradores e o total que atende ao filtro.
+// Dados bancários não são incluídos na listagem.
+func (r *ColaboradorRepository) List(filtro model.ColaboradorFiltro) ([]*model.Colaborador, int, error) {
+	where := " WHERE 1=1"
+	params := []interface{}{}
+	paramCount := 1
+
+	if filtro.Busca != "" {
+		where += fmt.Sprintf(" AND (u.nome ILIKE $%d OR u.email ILIKE $%d)", paramCount, paramCount)
+		params = append(params, "%"+escapeLike(filtro.Busca)+"%")
+		paramCount++
+	}
+
+	if filtro.CargoID > 0 {
+		where += fmt.Sprintf(" AND u.cargo_id = $%d", paramCount)
+		params = append(params, filtro.CargoID)
+		paramCount++
+	}
+
+	if filtro.GestorID > 0 {
+		where += fmt.Sprintf(" AND u.gestor_id = $%d", paramCount)
+		params = append(params, filtro.GestorID)
+		paramCount++
+	}
+
+	if filtro.Status != "" {
+		where += fmt.Sprintf(" AND u.status = $%d", paramCount)
+		params = append(params, filtro.Status)
+		paramCount++
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM usuarios u"+where, params...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT u.id, u.uuid, u.nome, u.email, u.cargo_id, COALESCE(c.nome, ''),
+		       u.gestor_id, COALESCE(g.nome, ''), u.data_admissao, u.status,
+		       u.foto_perfil, u.telefone, u.criado_em, u.atualizado_em
+		FROM usuarios u
+		LEFT JOIN cargos c ON u.cargo_id = c.id
+		LEFT JOIN usuarios g ON u.gestor_id = g.id` + where + `
+		ORDER BY u.nome, u.id`
+
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramCount, paramCount+1)
+	params = append(params, filtro.Limit, filtro.Offset)
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	colaboradores := []*model.Colaborador{}
+	for rows.Next() {
+		colaborador := &model.Colaborador{}
+		err := rows.Scan(
+			&colaborador.ID,
+			&colaborador.UUID,
+			&colaborador.Nome,
+			&colaborador.Email,
+			&colaborador.CargoID,
+			&colaborador.CargoNome,
+			&colaborador.GestorID,
+			&colaborador.GestorNome,
+			&colaborador.DataAdmissao,
+			&colaborador.Status,
+			&colaborador.FotoPerfil,
+			&colaborador.Telefone,
+			&colaborador.CriadoEm,
+			&colaborador.AtualizadoEm,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		colaboradores = append(colaboradores, colaborador)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return colaboradores, total, nil
+}
+
+// UpdateCadastro atualiza os dados cadastrais mantidos pelo RH. Se o email
+// mudar, os links de redefinição de senha e de convite pendentes são
+// invalidados.
+func (r *ColaboradorRepository) UpdateCadastro(colaborador *model.Colaborador, alteradoPor int) error {
+	query := `
+		UPDATE usuarios
+		SET nome = $1, email = $2, cargo_id = $3, data_admissao = $4,
+		    telefone = $5, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING atualizado_em
+	`
+
+	return r.alterarComHistorico(colaborador.ID, alteradoPor, func(tx *sql.Tx) error {
+		var emailAnterior string
+		if err := tx.QueryRow(`SELECT email FROM usuarios WHERE id = $1`, colaborador.ID).Scan(&emailAnterior); err != nil {
+			return err
+		}
+
+		err := tx.QueryRow(
+			query,
+			colaborador.Nome,
+			colaborador.Email,
+			colaborador.CargoID,
+			colaborador.DataAdmissao,
+			colaborador.Telefone,
+			colaborador.ID,
+		).Scan(&colaborador.AtualizadoEm)
+		if err != nil {
+			return erroEmailDuplicado(err)
+		}
+
+		// Links de redefinição e convites enviados ao email antigo deixam de valer
+		if !strings.EqualFold(emailAnterior, colaborador.Email) {
+			return invalidarTokensRedefinicao(tx, colaborador.ID)
+		}
+		return nil
+	})
+}
+
+// UpdateGestor define o gestor direto do colaborador (nil remove) e leva
+// para o novo gestor os documentos pendentes do colaborador. Retorna false,
+// sem alterar nada, se o colaborador estiver acima do novo gestor na
+// hierarquia, o que criaria um ciclo.
+func (r *ColaboradorRepository) UpdateGestor(id int, gestorID *int, alteradoPor int) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Serializa as alterações de hierarquia: duas alterações simultâneas
+	// poderiam criar um ciclo que nenhuma delas vê sozinha
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('usuarios.gestor_id'))`); err != nil {
+		return false, err
+	}
+
+	antes, err := dadosColaborador(tx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if gestorID != nil {
+		var ciclo bool
+		err := tx.QueryRow(`
+			WITH RECURSIVE acima(id) AS (
+				SELECT $1::INTEGER
+				UNION
+				SELECT u.gestor_id FROM usuarios u JOIN acima a ON u.id = a.id
+				WHERE u.gestor_id IS NOT NULL
+			)
+			SELECT EXISTS (SELECT 1 FROM acima WHERE id = $2)
+		`, *gestorID, id).Scan(&ciclo)
+		if err != nil {
+			return false, err
+		}
+		if ciclo {
+			return false, nil
+		}
+	}
+
+	result, err := tx.Exec(
+		`UPDATE usuarios SET gestor_id = $1, atualizado_em = CURRENT_TIMESTAMP WHERE id = $2`,
+		gestorID, id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rowsAffected == 0 {
+		return false, errors.New("colaborador não encontrado")
+	}
+
+	_, err = tx.Exec(
+		`UPDATE recibos SET aprovador_id = $1 WHERE usuario_id = $2 AND status = 'pendente'`,
+		gestorID, id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	depois, err := dadosColaborador(tx, id)
+	if err != nil {
+		return false, err
+	}
+	if err := registrarHistoricoColaborador(tx, id, AcaoColaboradorAtualizado, alteradoPor, antes, depois); err != nil {
+		return false, err
+	}
 
+	return true, tx.Commit()
+}
+
+// ExistingEmails retorna, dentre os emails informados, os que já estão
+// cadastrados, em minúsculas
+func (r *ColaboradorRepository) ExistingEmails(emails []string) (map[string]bool, error) {
+	minusculos := make([]string, len(emails))
+	for i, email := range emails {
+		minusculos[i] = strings.ToLower(email)
+	}
+
+	rows, err := r.db.Query(`SELECT LOWER(email) FROM usuarios WHERE LOWER(email) = ANY($1)`, pq.Array(minusculos))
 	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	existentes := map[string]bool{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		existentes[email] = true
+	}
+
+	return existentes, rows.Err()
+}
+
+// UpdateDadosBancarios grava o envelope cifrado dos dados bancários. O
+// histórico registra apenas que foram alterados.
+func (r *ColaboradorRepository) UpdateDadosBancarios(id int, cifrados []byte, alteradoPor int) error {
+	query := `
+		UPDATE usuarios
+		SET dados_bancarios = $1, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	return r.alterarComHistorico(id, alteradoPor, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, cifrados, id)
 		return err
+	})
+}
+
+// ListDadosBancarios retorna os dados bancários gravados de todos os
+// colaboradores que os possuem, indexados pelo ID
+func (r *ColaboradorRepository) ListDadosBancarios() (map[int][]byte, error) {
+	rows, err := r.db.Query(`SELECT id, dados_bancarios FROM usuarios WHERE dados_bancarios IS NOT NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dados := map[int][]byte{}
+	for rows.Next() {
+		var id int
+		var cifrados []byte
+		if err := rows.Scan(&id, &cifrados); err != nil {
+			return nil, err
+		}
+		dados[id] = cifrados
 	}
 
-	return nil
+	return dados, rows.Err()
 }
 
+// ReplaceDadosBancarios troca os dados bancários apenas se ainda forem os
+// lidos anteriormente, para não sobrescrever uma alteração concorrente.
+// Não altera atualizado_em, pois o conteúdo continua o mesmo.
+func (r *ColaboradorRepository) ReplaceDadosBancarios(id int, anteriores, novos []byte) (bool, error) {
+	result, err := r.db.Exec(
+		`UPDATE usuarios SET dados_bancarios = $1 WHERE id = $2 AND dados_bancarios = $3`,
+		novos, id, anteriores,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// escapeLike escapa os curingas do LIKE para buscar o texto literal
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// HashPassword gera o hash da senha com o algoritmo e os parâmetros atuais
+func (r *ColaboradorRepository) HashPassword(password string) (string, error) {
+	return r.hasher.Hash(password)
+}
+
+// VerifyPassword aceita hashes em qualquer formato suportado (argon2id ou bcrypt)
 func (r *ColaboradorRepository) VerifyPassword(storedHash, password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(password))
-	return err == nil
+	return r.hasher.Verify(storedHash, password)
+}
+
+// NeedsRehash informa se o hash deve ser refeito com os parâmetros atuais
+func (r *ColaboradorRepository) NeedsRehash(storedHash string) bool {
+	return r.hasher.NeedsRehash(storedHash)
 }
 
 // GetByIDWithPassword obtém o colaborador por ID incluindo a senha hasheada
 func (r *ColaboradorRepository) GetByIDWithPassword(id int) (*model.Colaborador, error) {
 	query := `
-		SELECT id, uuid, nome, email, senha, cargo_id, data_admissao, status, 
-		       foto_perfil, telefone, dados_bancarios, criado_em, atualizado_em
-		FROM usuarios
-		WHERE id = $1
+		SELECT u.id, u.uuid, u.nome, u.email, u.senha, u.cargo_id, COALESCE(c.nome, ''),
+		       u.gestor_id, COALESCE(g.nome, ''), u.data_admissao, u.status,
+		       u.foto_perfil, u.telefone, u.dados_bancarios, u.criado_em, u.atualizado_em
+		FROM usuarios u
+		LEFT JOIN cargos c ON u.cargo_id = c.id
+		LEFT JOIN usuarios g ON u.gestor_id = g.id
+		WHERE u.id = $1
 	`
 
 	colaborador := &model.Colaborador{}
@@ -175,11 +608,14 @@ func (r *ColaboradorRepository) GetByIDWithPassword(id int) (*model.Colaborador,
 		&colaborador.Email,
 		&colaborador.Senha,
 		&colaborador.CargoID,
+		&colaborador.CargoNome,
+		&colaborador.GestorID,
+		&colaborador.GestorNome,
 		&colaborador.DataAdmissao,
 		&colaborador.Status,
 		&colaborador.FotoPerfil,
 		&colaborador.Telefone,
-		&colaborador.DadosBancarios,
+		&colaborador.DadosBancariosCifrados,
 		&colaborador.CriadoEm,
 		&colaborador.AtualizadoEm,
 	)
@@ -218,3 +654,45 @@ func (r *ColaboradorRepository) UpdatePassword(id int, hashedPassword string) er
 
 	return nil
 }
+
+// GetStatus retorna apenas o status do colaborador
+func (r *ColaboradorRepository) GetStatus(id int) (string, error) {
+	var status string
+	err := r.db.QueryRow(`SELECT status FROM usuarios WHERE id = $1`, id).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.New("colaborador não encontrado")
+		}
+		return "", err
+	}
+
+	return status, nil
+}
+
+// IsAnonimizado informa se os dados pessoais do colaborador já foram
+// anonimizados
+func (r *ColaboradorRepository) IsAnonimizado(id int) (bool, error) {
+	var anonimizado bool
+	err := r.db.QueryRow(`SELECT anonimizado_em IS NOT NULL FROM usuarios WHERE id = $1`, id).Scan(&anonimizado)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrColaboradorNaoEncontrado
+		}
+		return false, err
+	}
+
+	return anonimizado, nil
+}
+
+func (r *ColaboradorRepository) UpdateStatus(id int, status string, alteradoPor int) error {
+	query := `
+		UPDATE usuarios
+		SET status = $1, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`
+
+	return r.alterarComHistorico(id, alteradoPor, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, status, id)
+		return err
+	})
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"empresa-app/backend/internal/model"
+)
+
+type ContaServicoRepository struct {
+	db *sql.DB
+}
+
+func NewContaServicoRepository(db *sql.DB) *ContaServicoRepository {
+	return &ContaServicoRepository{db: db}
+}
+
+func (r *ContaServicoRepository) Create(conta *model.ContaServico) error {
+	query := `
+		INSERT INTO contas_servico (nome, descricao, criado_por)
+		VALUES ($1, $2, $3)
+		RETURNING id, ativo, criado_em, atualizado_em
+	`
+
+	return r.db.QueryRow(query, conta.Nome, conta.Descricao, conta.CriadoPor).
+		Scan(&conta.ID, &conta.Ativo, &conta.CriadoEm, &conta.AtualizadoEm)
+}
+
+func (r *ContaServicoRepository) GetByID(id int) (*model.ContaServico, error) {
+	query := `
+		SELECT id, nome, descricao, ativo, criado_por, criado_em, atualizado_em
+		FROM contas_servico
+		WHERE id = $1
+	`
+
+	conta := &model.ContaServico{}
+	var criadoPor sql.NullInt32
+
+	err := r.db.QueryRow(query, id).Scan(
+		&conta.ID,
+		&conta.Nome,
+		&conta.Descricao,
+		&conta.Ativo,
+		&criadoPor,
+		&conta.CriadoEm,
+		&conta.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("conta de serviço não encontrada")
+		}
+		return nil, err
+	}
+
+	if criadoPor.Valid {
+		val := int(criadoPor.Int32)
+		conta.CriadoPor = &val
+	}
+
+	return conta, nil
+}
+
+func (r *ContaServicoRepository) List() ([]*model.ContaServico, error) {
+	query := `
+		SELECT id, nome, descricao, ativo, criado_por, criado_em, atualizado_em
+		FROM contas_servico
+		ORDER BY nome ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contas := []*model.ContaServico{}
+	for rows.Next() {
+		conta := &model.ContaServico{}
+		var criadoPor sql.NullInt32
+
+		err := rows.Scan(
+			&conta.ID,
+			&conta.Nome,
+			&conta.Descricao,
+			&conta.Ativo,
+			&criadoPor,
+			&conta.CriadoEm,
+			&conta.AtualizadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if criadoPor.Valid {
+			val := int(criadoPor.Int32)
+			conta.CriadoPor = &val
+		}
+
+		contas = append(contas, conta)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contas, nil
+}
+
+// Deactivate desativa a conta e revoga todas as suas chaves
+func (r *ContaServicoRepository) Deactivate(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE contas_servico
+		SET ativo = FALSE, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("conta de serviço não encontrada")
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE chaves_api
+		SET revogado_em = $1
+		WHERE conta_servico_id = $2 AND revogado_em IS NULL
+	`, time.Now(), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ContaServicoRepository) CreateChave(chave *model.ChaveAPI) error {
+	query := `
+		INSERT INTO chaves_api (conta_servico_id, prefixo, segredo_hash, escopos, expira_em, criado_por)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		chave.ContaServicoID,
+		chave.Prefixo,
+		chave.SegredoHash,
+		pq.Array(chave.Escopos),
+		chave.ExpiraEm,
+		chave.CriadoPor,
+	).Scan(&chave.ID, &chave.CriadoEm)
+}
+
+// GetChaveAtivaByPrefixo retorna a chave pelo prefixo, desde que a conta de
+// serviço esteja ativa
+func (r *ContaServicoRepository) GetChaveAtivaByPrefixo(prefixo string) (*model.ChaveAPI, error) {
+	query := `
+		SELECT k.id, k.conta_servico_id, k.prefixo, k.segredo_hash, k.escopos,
+		       k.expira_em, k.revogado_em, k.ultimo_uso_em, k.criado_por, k.criado_em
+		FROM chaves_api k
+		JOIN contas_servico c ON k.conta_servico_id = c.id
+		WHERE k.prefixo = $1 AND c.ativo
+	`
+
+	rows, err := r.db.Query(query, prefixo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chaves, err := scanChaves(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chaves) == 0 {
+		return nil, errors.New("chave de API não encontrada")
+	}
+
+	return chaves[0], nil
+}
+
+func (r *ContaServicoRepository) ListChaves(contaServicoID int) ([]*model.ChaveAPI, error) {
+	query := `
+		SELECT id, conta_servico_id, prefixo, segredo_hash, escopos,
+		       expira_em, revogado_em, ultimo_uso_em, criado_por, criado_em
+		FROM chaves_api
+		WHERE conta_servico_id = $1
+		ORDER BY criado_em DESC
+	`
+
+	rows, err := r.db.Query(query, contaServicoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanChaves(rows)
+}
+
+// RevokeChave revoga uma chave de API
+func (r *ContaServicoRepository) RevokeChave(id int) error {
+	query := `
+		UPDATE chaves_api
+		SET revogado_em = $1
+		WHERE id = $2 AND revogado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("chave de API não encontrada ou já revogada")
+	}
+
+	return nil
+}
+
+// TouchChave registra o último uso da chave
+func (r *ContaServicoRepository) TouchChave(id int) error {
+	_, err := r.db.Exec(`UPDATE chaves_api SET ultimo_uso_em = $1 WHERE id = $2`, time.Now(), id)
+	return err
+}
+
+func scanChaves(rows *sql.Rows) ([]*model.ChaveAPI, error) {
+	chaves := []*model.ChaveAPI{}
+	for rows.Next() {
+		chave := &model.ChaveAPI{}
+		var expiraEm, revogadoEm, ultimoUsoEm sql.NullTime
+		var criadoPor sql.NullInt32
+
+		err := rows.Scan(
+			&chave.ID,
+			&chave.ContaServicoID,
+			&chave.Prefixo,
+			&chave.SegredoHash,
+			pq.Array(&chave.Escopos),
+			&expiraEm,
+			&revogadoEm,
+			&ultimoUsoEm,
+			&criadoPor,
+			&chave.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if expiraEm.Valid {
+			chave.ExpiraEm = &expiraEm.Time
+		}
+		if revogadoEm.Valid {
+			chave.RevogadoEm = &revogadoEm.Time
+		}
+		if ultimoUsoEm.Valid {
+			chave.UltimoUsoEm = &ultimoUsoEm.Time
+		}
+		if criadoPor.Valid {
+			val := int(criadoPor.Int32)
+			chave.CriadoPor = &val
+		}
+
+		chaves = append(chaves, chave)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return chaves, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"empresa-app/backend/internal/model"
+)
+
+type DepartamentoRepository struct {
+	db *sql.DB
+}
+
+func NewDepartamentoRepository(db *sql.DB) *DepartamentoRepository {
+	return &DepartamentoRepository{db: db}
+}
+
+func (r *DepartamentoRepository) GetByID(id int) (*model.Departamento, error) {
+	query := `
+		SELECT id, nome, descricao, ativo, criado_em, atualizado_em
+		FROM departamentos
+		WHERE id = $1
+	`
+
+	departamento := &model.Departamento{}
+	err := r.db.QueryRow(query, id).Scan(
+		&departamento.ID,
+		&departamento.Nome,
+		&departamento.Descricao,
+		&departamento.Ativo,
+		&departamento.CriadoEm,
+		&departamento.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("departamento não encontrado")
+		}
+		return nil, err
+	}
+
+	return departamento, nil
+}
+
+// List retorna os departamentos com a quantidade de colaboradores alocados
+// hoje em cada um
+func (r *DepartamentoRepository) List(incluirInativos bool) ([]*model.Departamento, error) {
+	query := `
+		SELECT d.id, d.nome, d.descricao, d.ativo, d.criado_em, d.atualizado_em,
+		       (SELECT COUNT(*) FROM alocacoes_colaborador a
+		        WHERE a.departamento_id = d.id ` + vigenteHoje + `)
+		FROM departamentos d
+		WHERE d.ativo OR $1
+		ORDER BY d.nome
+	`
+
+	rows, err := r.db.Query(query, incluirInativos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	departamentos := []*model.Departamento{}
+	for rows.Next() {
+		departamento := &model.Departamento{}
+		err := rows.Scan(
+			&departamento.ID,
+			&departamento.Nome,
+			&departamento.Descricao,
+			&departamento.Ativo,
+			&departamento.CriadoEm,
+			&departamento.AtualizadoEm,
+			&departamento.TotalColaboradores,
+		)
+		if err != nil {
+			return nil, err
+		}
+		departamentos = append(departamentos, departamento)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return departamentos, nil
+}
+
+// ExistsNome informa se outro departamento já usa o nome (sem diferenciar maiúsculas)
+func (r *DepartamentoRepository) ExistsNome(nome string, ignorarID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM departamentos WHERE LOWER(nome) = LOWER($1) AND id <> $2)`,
+		nome, ignorarID,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *DepartamentoRepository) Create(departamento *model.Departamento) error {
+	query := `
+		INSERT INTO departamentos (nome, descricao, ativo)
+		VALUES ($1, $2, $3)
+		RETURNING id, criado_em, atualizado_em
+	`
+
+	return r.db.QueryRow(query, departamento.Nome, departamento.Descricao, departamento.Ativo).
+		Scan(&departamento.ID, &departamento.CriadoEm, &departamento.AtualizadoEm)
+}
+
+func (r *DepartamentoRepository) Update(departamento *model.Departamento) error {
+	query := `
+		UPDATE departamentos
+		SET nome = $1, descricao = $2, ativo = $3, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $4
+		RETURNING atualizado_em
+	`
+
+	err := r.db.QueryRow(query, departamento.Nome, departamento.Descricao, departamento.Ativo, departamento.ID).
+		Scan(&departamento.AtualizadoEm)
+	if err == sql.ErrNoRows {
+		return errors.New("departamento não encontrado")
+	}
+	return err
+}
+
+// Delete exclui o departamento se ele nunca teve colaboradores alocados,
+// preservando o histórico. Retorna false se o departamento não existe ou já
+// foi usado.
+func (r *DepartamentoRepository) Delete(id int) (bool, error) {
+	query := `
+		DELETE FROM departamentos
+		WHERE id = $1
+		  AND NOT EXISTS (SELECT 1 FROM alocacoes_colaborador WHERE departamento_id = $1)
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
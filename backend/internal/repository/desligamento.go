@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"empresa-app/backend/internal/model"
+)
+
+type DesligamentoRepository struct {
+	db *sql.DB
+}
+
+func NewDesligamentoRepository(db *sql.DB) *DesligamentoRepository {
+	return &DesligamentoRepository{db: db}
+}
+
+// ResultadoDesligamento informa o que foi alterado pelo desligamento
+type ResultadoDesligamento struct {
+	StatusAnterior           string
+	Subordinados             int
+	AprovacoesReencaminhadas int
+	DocumentosRejeitados     int
+	DocumentosMantidos       int
+	AlocacaoEncerrada        bool
+	RegistrosPonto           []*model.RegistroPonto
+}
+
+// Desligar aplica o desligamento em uma única transação: muda o status para
+// desligado; passa os subordinados diretos ao substituto (ou, sem ele, ao
+// gestor do desligado) e reencaminha ao novo gestor dos autores os
+// documentos que aguardavam a aprovação do desligado; rejeita ou mantém os
+// documentos pendentes enviados por ele; encerra a alocação na data do
+// desligamento; e grava o registro do desligamento. Retorna os registros de
+// ponto desde pontoDesde para o espelho. Retorna false se o colaborador já
+// está desligado ou se o substituto ficou abaixo da hierarquia dele.
+func (r *DesligamentoRepository) Desligar(d *model.Desligamento, rejeitarDocumentos bool, pontoDesde string) (*ResultadoDesligamento, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	// Mesma trava de UpdateGestor: a hierarquia é alterada abaixo
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('usuarios.gestor_id'))`); err != nil {
+		return nil, false, err
+	}
+
+	resultado := &ResultadoDesligamento{}
+	var gestorID sql.NullInt64
+	err = tx.QueryRow(`SELECT status, gestor_id FROM usuarios WHERE id = $1 FOR UPDATE`, d.ColaboradorID).
+		Scan(&resultado.StatusAnterior, &gestorID)
+	if err != nil {
+		return nil, false, err
+	}
+	if resultado.StatusAnterior == "desligado" {
+		return nil, false, nil
+	}
+
+	// Os subordinados vão para o substituto; se o substituto é um deles, ele
+	// assume o gestor do desligado. Abaixo disso, criaria um ciclo.
+	destino := gestorID
+	if d.SubstitutoID != nil {
+		var abaixo bool
+		err := tx.QueryRow(`
+			WITH RECURSIVE abaixo(id) AS (
+				SELECT u.id FROM usuarios u JOIN usuarios s ON u.gestor_id = s.id
+				WHERE s.gestor_id = $1
+				UNION
+				SELECT u.id FROM usuarios u JOIN abaixo a ON u.gestor_id = a.id
+			)
+			SELECT EXISTS (SELECT 1 FROM abaixo WHERE id = $2)
+		`, d.ColaboradorID, *d.SubstitutoID).Scan(&abaixo)
+		if err != nil {
+			return nil, false, err
+		}
+		if abaixo {
+			return nil, false, nil
+		}
+		destino = sql.NullInt64{Int64: int64(*d.SubstitutoID), Valid: true}
+	}
+
+	// Dados anteriores do desligado e dos subordinados, para o histórico
+	antes, err := dadosColaborador(tx, d.ColaboradorID)
+	if err != nil {
+		return nil, false, err
+	}
+	antesSubordinados, err := dadosSubordinados(tx, d.ColaboradorID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE usuarios SET status = 'desligado', atualizado_em = CURRENT_TIMESTAMP WHERE id = $1`,
+		d.ColaboradorID,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE usuarios
+		SET gestor_id = CASE WHEN id = $2::INTEGER THEN $3::INTEGER ELSE $2::INTEGER END,
+		    atualizado_em = CURRENT_TIMESTAMP
+		WHERE gestor_id = $1
+	`, d.ColaboradorID, destino, gestorID)
+	if err != nil {
+		return nil, false, err
+	}
+	if resultado.Subordinados, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	realizadoPor := 0
+	if d.RealizadoPor != nil {
+		realizadoPor = *d.RealizadoPor
+	}
+	for id, anteriores := range antesSubordinados {
+		depois, err := dadosColaborador(tx, id)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := registrarHistoricoColaborador(tx, id, AcaoColaboradorAtualizado, realizadoPor, anteriores, depois); err != nil {
+			return nil, false, err
+		}
+	}
+	depois, err := dadosColaborador(tx, d.ColaboradorID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := registrarHistoricoColaborador(tx, d.ColaboradorID, AcaoColaboradorDesligado, realizadoPor, antes, depois); err != nil {
+		return nil, false, err
+	}
+
+	// Sem novo gestor, o documento fica com o RH (aprovador nulo)
+	result, err = tx.Exec(`
+		UPDATE recibos r
+		SET aprovador_id = u.gestor_id
+		FROM usuarios u
+		WHERE r.usuario_id = u.id AND r.aprovador_id = $1 AND r.status = 'pendente'
+		  AND r.usuario_id <> $1
+	`, d.ColaboradorID)
+	if err != nil {
+		return nil, false, err
+	}
+	if resultado.AprovacoesReencaminhadas, err = linhasAfetadas(result); err != nil {
+		return nil, false, err
+	}
+
+	if rejeitarDocumentos {
+		result, err = tx.Exec(`
+			UPDATE recibos
+			SET status = 'rejeitado', aprovado_por = $2, data_aprovacao = CURRENT_TIMESTAMP, atualizado_em = CURRENT_TIMESTAMP
+			WHERE usuario_id = $1 AND status = 'pendente'
+		`, d.ColaboradorID, d.RealizadoPor)
+		if err != nil {
+			return nil, false, err
+		}
+		if resultado.DocumentosRejeitados, err = linhasAfetadas(result); err != nil {
+			return nil, false, err
+		}
+	} else {
+		err = tx.QueryRow(`SELECT COUNT(*) FROM recibos WHERE usuario_id = $1 AND status = 'pendente'`, d.ColaboradorID).
+			Scan(&resultado.DocumentosMantidos)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	data := d.DataDesligamento.Format("2006-01-02")
+	if _, err := tx.Exec(
+		`DELETE FROM alocacoes_colaborador WHERE usuario_id = $1 AND vigencia_inicio > $2::date`,
+		d.ColaboradorID, data,
+	); err != nil {
+		return nil, false, err
+	}
+	result, err = tx.Exec(`
+		UPDATE alocacoes_colaborador
+		SET vigencia_fim = $2::date
+		WHERE usuario_id = $1 AND (vigencia_fim IS NULL OR vigencia_fim > $2::date)
+	`, d.ColaboradorID, data)
+	if err != nil {
+		return nil, false, err
+	}
+	encerradas, err := linhasAfetadas(result)
+	if err != nil {
+		return nil, false, err
+	}
+	resultado.AlocacaoEncerrada = encerradas > 0
+
+	if resultado.RegistrosPonto, err = listarRegistrosPonto(tx, d.ColaboradorID, pontoDesde, data); err != nil {
+		return nil, false, err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO desligamentos (usuario_id, data_desligamento, motivo, substituto_id, realizado_por)
+		VALUES ($1, $2::date, $3, $4, $5)
+		RETURNING id, criado_em
+	`, d.ColaboradorID, data, d.Motivo, d.SubstitutoID, d.RealizadoPor).Scan(&d.ID, &d.CriadoEm)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	return resultado, true, nil
+}
+
+// SalvarRelatorio grava o checklist e o espelho de ponto do desligamento
+func (r *DesligamentoRepository) SalvarRelatorio(id int, checklist []*model.ItemChecklistDesligamento, espelho *model.EspelhoPonto) error {
+	checklistJSON, err := json.Marshal(checklist)
+	if err != nil {
+		return err
+	}
+	espelhoJSON, err := json.Marshal(espelho)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(
+		`UPDATE desligamentos SET checklist = $2, espelho_ponto = $3 WHERE id = $1`,
+		id, checklistJSON, espelhoJSON,
+	)
+	return err
+}
+
+// ListByColaborador retorna os desligamentos do colaborador, do mais recente
+// para o mais antigo (um colaborador pode ser recontratado)
+func (r *DesligamentoRepository) ListByColaborador(colaboradorID int) ([]*model.Desligamento, error) {
+	query := `
+		SELECT d.id, d.usuario_id, d.data_desligamento, d.motivo, d.substituto_id,
+		       d.realizado_por, COALESCE(u.nome, ''), d.checklist, d.espelho_ponto, d.criado_em
+		FROM desligamentos d
+		LEFT JOIN usuarios u ON d.realizado_por = u.id
+		WHERE d.usuario_id = $1
+		ORDER BY d.criado_em DESC, d.id DESC
+	`
+
+	rows, err := r.db.Query(query, colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	desligamentos := []*model.Desligamento{}
+	for rows.Next() {
+		d := &model.Desligamento{}
+		var checklist, espelho []byte
+
+		err := rows.Scan(
+			&d.ID,
+			&d.ColaboradorID,
+			&d.DataDesligamento,
+			&d.Motivo,
+			&d.SubstitutoID,
+			&d.RealizadoPor,
+			&d.RealizadoPorNome,
+			&checklist,
+			&espelho,
+			&d.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(checklist, &d.Checklist); err != nil {
+			return nil, err
+		}
+		if espelho != nil {
+			if err := json.Unmarshal(espelho, &d.EspelhoPonto); err != nil {
+				return nil, err
+			}
+		}
+
+		desligamentos = append(desligamentos, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return desligamentos, nil
+}
+
+// dadosSubordinados lê, para o histórico, os dados dos subordinados diretos
+// do colaborador, indexados pelo ID
+func dadosSubordinados(tx *sql.Tx, gestorID int) (map[int]map[string]interface{}, error) {
+	rows, err := tx.Query(`SELECT id FROM usuarios WHERE gestor_id = $1`, gestorID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	subordinados := make(map[int]map[string]interface{}, len(ids))
+	for _, id := range ids {
+		dados, err := dadosColaborador(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		subordinados[id] = dados
+	}
+
+	return subordinados, nil
+}
+
+// listarRegistrosPonto retorna os registros do colaborador entre as datas
+// (inclusive), em ordem cronológica
+func listarRegistrosPonto(tx *sql.Tx, colaboradorID int, inicio, fim string) ([]*model.RegistroPonto, error) {
+	rows, err := tx.Query(`
+		SELECT id, usuario_id, tipo, data_hora, localizacao, ip_origem,
+		       dispositivo, observacao, criado_em
+		FROM registros_ponto
+		WHERE usuario_id = $1 AND DATE(data_hora) BETWEEN $2::date AND $3::date
+		ORDER BY data_hora ASC
+	`, colaboradorID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRegistrosPonto(rows)
+}
+
+func linhasAfetadas(result sql.Result) (int, error) {
+	n, err := result.RowsAffected()
+	return int(n), err
+}
@@ -21,8 +21,8 @@ func (r *DocumentoRepository) Create(doc *model.Documento) error {
 	query := `
 		INSERT INTO recibos (
 			usuario_id, titulo, descricao, categoria, data, 
-			valor, anexo_url, dados_adicionais, status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			valor, anexo_url, dados_adicionais, status, aprovador_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, uuid, criado_em, atualizado_em
 	`
 
@@ -37,6 +37,7 @@ func (r *DocumentoRepository) Create(doc *model.Documento) error {
 		doc.CaminhoArquivo,
 		doc.DadosAdicionais,
 		"pendente", // Status inicial
+		doc.AprovadorID,
 	).Scan(
 		&doc.ID,
 		&doc.UUID,
@@ -55,7 +56,7 @@ func (r *DocumentoRepository) GetByID(id int) (*model.Documento, error) {
 	query := `
 		SELECT 
 			r.id, r.usuario_id, r.titulo, r.descricao, r.categoria, r.data,
-			r.valor, r.anexo_url, r.status, r.aprovado_por, r.data_aprovacao,
+			r.valor, r.anexo_url, r.status, r.aprovador_id, r.aprovado_por, r.data_aprovacao,
 			r.criado_em, r.atualizado_em, u.uuid
 		FROM recibos r
 		JOIN usuarios u ON r.usuario_id = u.id
@@ -78,6 +79,7 @@ func (r *DocumentoRepository) GetByID(id int) (*model.Documento, error) {
 		&doc.Valor,
 		&doc.CaminhoArquivo,
 		&doc.Status,
+		&doc.AprovadorID,
 		&aprovadoPor,
 		&dataAprovacao,
 		&doc.CriadoEm,
@@ -110,11 +112,11 @@ func (r *DocumentoRepository) GetByID(id int) (*model.Documento, error) {
 	return doc, nil
 }
 
-func (r *DocumentoRepository) List(colaboradorID *int, status string, limit, offset int) ([]*model.Documento, error) {
+func (r *DocumentoRepository) List(filtro model.DocumentoFiltro) ([]*model.Documento, error) {
 	query := `
 		SELECT 
 			r.id, r.usuario_id, r.titulo, r.categoria, r.data,
-			r.valor, r.anexo_url, r.status, r.criado_em, u.uuid
+			r.valor, r.anexo_url, r.status, r.aprovador_id, r.criado_em, u.uuid
 		FROM recibos r
 		JOIN usuarios u ON r.usuario_id = u.id
 		WHERE 1=1
@@ -123,28 +125,33 @@ func (r *DocumentoRepository) List(colaboradorID *int, status string, limit, off
 	params := []interface{}{}
 	paramCount := 1
 
-	if colaboradorID != nil {
+	if filtro.ColaboradorID != nil {
 		query += fmt.Sprintf(" AND r.usuario_id = $%d", paramCount)
-		params = append(params, *colaboradorID)
+		params = append(params, *filtro.ColaboradorID)
 		paramCount++
 	}
 
-	if status != "" && status != "todos" {
+	if filtro.Status != "" && filtro.Status != "todos" {
 		query += fmt.Sprintf(" AND r.status = $%d", paramCount)
-		params = append(params, status)
+		params = append(params, filtro.Status)
 		paramCount++
 	}
 
+	// Departamento e centro de custo do colaborador na data do documento
+	condicao, params := filtroAlocacao("r.usuario_id", "r.data::date", filtro.DepartamentoID, filtro.CentroCustoID, params)
+	query += condicao
+	paramCount = len(params) + 1
+
 	query += " ORDER BY r.criado_em DESC"
 
-	if limit > 0 {
+	if filtro.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT $%d", paramCount)
-		params = append(params, limit)
+		params = append(params, filtro.Limit)
 		paramCount++
 
-		if offset > 0 {
+		if filtro.Offset > 0 {
 			query += fmt.Sprintf(" OFFSET $%d", paramCount)
-			params = append(params, offset)
+			params = append(params, filtro.Offset)
 		}
 	}
 
@@ -166,6 +173,7 @@ func (r *DocumentoRepository) List(colaboradorID *int, status string, limit, off
 			&doc.Valor,
 			&doc.CaminhoArquivo,
 			&doc.Status,
+			&doc.AprovadorID,
 			&doc.CriadoEm,
 			&doc.UUID,
 		)
@@ -182,6 +190,81 @@ func (r *DocumentoRepository) List(colaboradorID *int, status string, limit, off
 	return docs, nil
 }
 
+// ListPendentesAprovacao retorna os documentos pendentes que aguardam a
+// decisão do colaborador: os encaminhados a ele como gestor e, se incluirRH,
+// os sem gestor ou cujo gestor não está ativo. Os documentos do próprio
+// colaborador nunca são incluídos.
+func (r *DocumentoRepository) ListPendentesAprovacao(colaboradorID int, incluirRH bool, limit, offset int) ([]*model.Documento, error) {
+	query := `
+		SELECT
+			r.id, r.usuario_id, r.titulo, r.categoria, r.data,
+			r.valor, r.anexo_url, r.status, r.aprovador_id, r.criado_em, u.uuid
+		FROM recibos r
+		JOIN usuarios u ON r.usuario_id = u.id
+		LEFT JOIN usuarios a ON r.aprovador_id = a.id
+		WHERE r.status = 'pendente' AND r.usuario_id <> $1
+		  AND (r.aprovador_id = $1 OR ($2 AND (a.id IS NULL OR a.status <> 'ativo')))
+		ORDER BY r.criado_em
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(query, colaboradorID, incluirRH, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	docs := []*model.Documento{}
+	for rows.Next() {
+		doc := &model.Documento{}
+		err := rows.Scan(
+			&doc.ID,
+			&doc.ColaboradorID,
+			&doc.Titulo,
+			&doc.TipoDocumento,
+			&doc.DataDocumento,
+			&doc.Valor,
+			&doc.CaminhoArquivo,
+			&doc.Status,
+			&doc.AprovadorID,
+			&doc.CriadoEm,
+			&doc.UUID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// Decide aprova ou rejeita o documento se ele ainda estiver pendente.
+// Retorna false se outro aprovador já decidiu.
+func (r *DocumentoRepository) Decide(id int, status string, aprovadoPor int) (bool, error) {
+	query := `
+		UPDATE recibos
+		SET status = $1, aprovado_por = $2, data_aprovacao = $3, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $4 AND status = 'pendente'
+	`
+
+	result, err := r.db.Exec(query, status, aprovadoPor, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (r *DocumentoRepository) UpdateStatus(id int, status string, aprovadoPor int) error {
 	var query string
 	var params []interface{}
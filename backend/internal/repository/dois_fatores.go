@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+// ErrDoisFatoresNaoConfigurado indica que o colaborador nunca iniciou o
+// cadastro do 2FA
+var ErrDoisFatoresNaoConfigurado = errors.New("2FA não configurado")
+
+type DoisFatoresRepository struct {
+	db *sql.DB
+}
+
+func NewDoisFatoresRepository(db *sql.DB) *DoisFatoresRepository {
+	return &DoisFatoresRepository{db: db}
+}
+
+func (r *DoisFatoresRepository) GetByColaborador(colaboradorID int) (*model.DoisFatores, error) {
+	query := `
+		SELECT usuario_id, segredo, ativado_em, ultimo_passo, criado_em
+		FROM dois_fatores
+		WHERE usuario_id = $1
+	`
+
+	config := &model.DoisFatores{}
+	var ativadoEm sql.NullTime
+
+	err := r.db.QueryRow(query, colaboradorID).Scan(
+		&config.ColaboradorID,
+		&config.Segredo,
+		&ativadoEm,
+		&config.UltimoPasso,
+		&config.CriadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDoisFatoresNaoConfigurado
+		}
+		return nil, err
+	}
+
+	if ativadoEm.Valid {
+		config.AtivadoEm = &ativadoEm.Time
+	}
+
+	return config, nil
+}
+
+// SaveSecret grava um novo segredo ainda não ativado, substituindo um
+// cadastro pendente anterior. Não altera um 2FA já ativo.
+func (r *DoisFatoresRepository) SaveSecret(colaboradorID int, segredo string) error {
+	query := `
+		INSERT INTO dois_fatores (usuario_id, segredo)
+		VALUES ($1, $2)
+		ON CONFLICT (usuario_id) DO UPDATE
+		SET segredo = EXCLUDED.segredo, ultimo_passo = 0, criado_em = CURRENT_TIMESTAMP
+		WHERE dois_fatores.ativado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, colaboradorID, segredo)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("2FA já está ativo")
+	}
+
+	return nil
+}
+
+// Activate ativa o 2FA e substitui os códigos de recuperação em uma transação
+func (r *DoisFatoresRepository) Activate(colaboradorID int, codigoHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE dois_fatores SET ativado_em = $1 WHERE usuario_id = $2`, time.Now(), colaboradorID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM codigos_recuperacao WHERE usuario_id = $1`, colaboradorID); err != nil {
+		return err
+	}
+
+	for _, hash := range codigoHashes {
+		if _, err := tx.Exec(`INSERT INTO codigos_recuperacao (usuario_id, codigo_hash) VALUES ($1, $2)`, colaboradorID, hash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateUltimoPasso registra o último intervalo TOTP aceito. Retorna false se
+// o intervalo não for posterior ao último registrado (código reutilizado).
+func (r *DoisFatoresRepository) UpdateUltimoPasso(colaboradorID int, passo int64) (bool, error) {
+	query := `
+		UPDATE dois_fatores
+		SET ultimo_passo = $1
+		WHERE usuario_id = $2 AND ultimo_passo < $1
+	`
+
+	result, err := r.db.Exec(query, passo, colaboradorID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// Delete remove o 2FA e os códigos de recuperação do colaborador
+func (r *DoisFatoresRepository) Delete(colaboradorID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM codigos_recuperacao WHERE usuario_id = $1`, colaboradorID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM dois_fatores WHERE usuario_id = $1`, colaboradorID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListUnusedRecoveryCodes retorna os códigos de recuperação ainda não usados
+func (r *DoisFatoresRepository) ListUnusedRecoveryCodes(colaboradorID int) ([]*model.CodigoRecuperacao, error) {
+	query := `
+		SELECT id, usuario_id, codigo_hash
+		FROM codigos_recuperacao
+		WHERE usuario_id = $1 AND usado_em IS NULL
+	`
+
+	rows, err := r.db.Query(query, colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codigos := []*model.CodigoRecuperacao{}
+	for rows.Next() {
+		codigo := &model.CodigoRecuperacao{}
+		if err := rows.Scan(&codigo.ID, &codigo.ColaboradorID, &codigo.CodigoHash); err != nil {
+			return nil, err
+		}
+		codigos = append(codigos, codigo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return codigos, nil
+}
+
+// MarkRecoveryCodeUsed consome o código de recuperação de forma atômica
+func (r *DoisFatoresRepository) MarkRecoveryCodeUsed(id int) (bool, error) {
+	query := `
+		UPDATE codigos_recuperacao
+		SET usado_em = $1
+		WHERE id = $2 AND usado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
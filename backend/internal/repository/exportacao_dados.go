@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type ExportacaoDadosRepository struct {
+	db *sql.DB
+}
+
+func NewExportacaoDadosRepository(db *sql.DB) *ExportacaoDadosRepository {
+	return &ExportacaoDadosRepository{db: db}
+}
+
+const colunasExportacaoDados = `
+	id, usuario_id, status, COALESCE(caminho_arquivo, ''), COALESCE(tamanho_bytes, 0),
+	COALESCE(erro, ''), expira_em, criado_em, concluido_em
+`
+
+// Create registra uma nova exportação em andamento. Retorna false se o
+// colaborador já tem uma em andamento.
+func (r *ExportacaoDadosRepository) Create(exportacao *model.ExportacaoDados) (bool, error) {
+	query := `
+		INSERT INTO exportacoes_dados (usuario_id)
+		VALUES ($1)
+		ON CONFLICT (usuario_id) WHERE status = 'processando' DO NOTHING
+		RETURNING id, status, criado_em
+	`
+
+	err := r.db.QueryRow(query, exportacao.ColaboradorID).
+		Scan(&exportacao.ID, &exportacao.Status, &exportacao.CriadoEm)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ExportacaoDadosRepository) GetByID(id int) (*model.ExportacaoDados, error) {
+	query := `SELECT ` + colunasExportacaoDados + ` FROM exportacoes_dados WHERE id = $1`
+
+	exportacao, err := scanExportacaoDados(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("exportação não encontrada")
+	}
+	return exportacao, err
+}
+
+// GetUltima retorna a exportação mais recente do colaborador, ou nil
+func (r *ExportacaoDadosRepository) GetUltima(colaboradorID int) (*model.ExportacaoDados, error) {
+	query := `
+		SELECT ` + colunasExportacaoDados + `
+		FROM exportacoes_dados
+		WHERE usuario_id = $1
+		ORDER BY criado_em DESC, id DESC
+		LIMIT 1
+	`
+
+	exportacao, err := scanExportacaoDados(r.db.QueryRow(query, colaboradorID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return exportacao, err
+}
+
+// Concluir marca a exportação como concluída, com o arquivo disponível até expiraEm
+func (r *ExportacaoDadosRepository) Concluir(id int, caminho string, tamanho int64, expiraEm time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE exportacoes_dados
+		SET status = 'concluida', caminho_arquivo = $2, tamanho_bytes = $3, expira_em = $4,
+		    concluido_em = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, id, caminho, tamanho, expiraEm)
+	return err
+}
+
+// Falhar marca a exportação como falha, guardando o erro
+func (r *ExportacaoDadosRepository) Falhar(id int, erro string) error {
+	_, err := r.db.Exec(`
+		UPDATE exportacoes_dados
+		SET status = 'falhou', erro = $2, concluido_em = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'processando'
+	`, id, erro)
+	return err
+}
+
+// ListExpiradas retorna as exportações concluídas cujo link já expirou
+func (r *ExportacaoDadosRepository) ListExpiradas(now time.Time) ([]*model.ExportacaoDados, error) {
+	query := `
+		SELECT ` + colunasExportacaoDados + `
+		FROM exportacoes_dados
+		WHERE status = 'concluida' AND expira_em <= $1
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exportacoes := []*model.ExportacaoDados{}
+	for rows.Next() {
+		exportacao, err := scanExportacaoDados(rows)
+		if err != nil {
+			return nil, err
+		}
+		exportacoes = append(exportacoes, exportacao)
+	}
+
+	return exportacoes, rows.Err()
+}
+
+// Expirar marca a exportação como expirada depois que o arquivo foi apagado
+func (r *ExportacaoDadosRepository) Expirar(id int) error {
+	_, err := r.db.Exec(
+		`UPDATE exportacoes_dados SET status = 'expirada', caminho_arquivo = NULL WHERE id = $1`,
+		id,
+	)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanExportacaoDados(row scanner) (*model.ExportacaoDados, error) {
+	exportacao := &model.ExportacaoDados{}
+	err := row.Scan(
+		&exportacao.ID,
+		&exportacao.ColaboradorID,
+		&exportacao.Status,
+		&exportacao.CaminhoArquivo,
+		&exportacao.TamanhoBytes,
+		&exportacao.Erro,
+		&exportacao.ExpiraEm,
+		&exportacao.CriadoEm,
+		&exportacao.ConcluidoEm,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return exportacao, nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"empresa-app/backend/internal/model"
+)
+
+type FotoPerfilRepository struct {
+	db *sql.DB
+}
+
+func NewFotoPerfilRepository(db *sql.DB) *FotoPerfilRepository {
+	return &FotoPerfilRepository{db: db}
+}
+
+func (r *FotoPerfilRepository) Get(colaboradorID int) (*model.FotoPerfil, error) {
+	query := `
+		SELECT usuario_id, caminho_grande, caminho_pequeno, versao, atualizado_em
+		FROM fotos_perfil
+		WHERE usuario_id = $1
+	`
+
+	foto := &model.FotoPerfil{}
+	err := r.db.QueryRow(query, colaboradorID).Scan(
+		&foto.ColaboradorID,
+		&foto.CaminhoGrande,
+		&foto.CaminhoPequeno,
+		&foto.Versao,
+		&foto.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("foto não encontrada")
+		}
+		return nil, err
+	}
+
+	return foto, nil
+}
+
+// Save grava a foto e a URL em usuarios.foto_perfil na mesma transação.
+// Retorna a foto substituída, cujos arquivos podem ser removidos.
+func (r *FotoPerfilRepository) Save(foto *model.FotoPerfil, url string) (*model.FotoPerfil, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	anterior, err := fotoAnterior(tx, foto.ColaboradorID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO fotos_perfil (usuario_id, caminho_grande, caminho_pequeno, versao)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (usuario_id) DO UPDATE
+		SET caminho_grande = EXCLUDED.caminho_grande,
+		    caminho_pequeno = EXCLUDED.caminho_pequeno,
+		    versao = EXCLUDED.versao,
+		    atualizado_em = CURRENT_TIMESTAMP
+		RETURNING atualizado_em
+	`
+
+	err = tx.QueryRow(query, foto.ColaboradorID, foto.CaminhoGrande, foto.CaminhoPequeno, foto.Versao).
+		Scan(&foto.AtualizadoEm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := atualizarURLFoto(tx, foto.ColaboradorID, url); err != nil {
+		return nil, err
+	}
+
+	return anterior, tx.Commit()
+}
+
+// Delete remove a foto e limpa usuarios.foto_perfil. Retorna a foto
+// removida, ou nil se o colaborador não tinha foto.
+func (r *FotoPerfilRepository) Delete(colaboradorID int) (*model.FotoPerfil, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	anterior, err := fotoAnterior(tx, colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+	if anterior == nil {
+		return nil, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM fotos_perfil WHERE usuario_id = $1`, colaboradorID); err != nil {
+		return nil, err
+	}
+
+	if err := atualizarURLFoto(tx, colaboradorID, ""); err != nil {
+		return nil, err
+	}
+
+	return anterior, tx.Commit()
+}
+
+func fotoAnterior(tx *sql.Tx, colaboradorID int) (*model.FotoPerfil, error) {
+	query := `
+		SELECT usuario_id, caminho_grande, caminho_pequeno, versao, atualizado_em
+		FROM fotos_perfil
+		WHERE usuario_id = $1
+		FOR UPDATE
+	`
+
+	foto := &model.FotoPerfil{}
+	err := tx.QueryRow(query, colaboradorID).Scan(
+		&foto.ColaboradorID,
+		&foto.CaminhoGrande,
+		&foto.CaminhoPequeno,
+		&foto.Versao,
+		&foto.AtualizadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return foto, nil
+}
+
+func atualizarURLFoto(tx *sql.Tx, colaboradorID int, url string) error {
+	result, err := tx.Exec(`
+		UPDATE usuarios
+		SET foto_perfil = $1, atualizado_em = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, url, colaboradorID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("colaborador não encontrado")
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+
+	"empresa-app/backend/internal/model"
+)
+
+// Ações registradas no histórico de colaboradores
+const (
+	AcaoColaboradorCriado      = "criado"
+	AcaoColaboradorAtualizado  = "atualizado"
+	AcaoColaboradorDesligado   = "desligado"
+	AcaoColaboradorAnonimizado = "anonimizado"
+)
+
+// CamposHistoricoColaborador são os campos do cadastro acompanhados pelo
+// histórico, na ordem em que aparecem. A senha fica de fora: as trocas estão
+// em historico_senhas.
+var CamposHistoricoColaborador = []string{
+	"nome", "email", "cargo", "gestor", "data_admissao", "status", "telefone", "dados_bancarios",
+}
+
+// camposSensiveis têm registrada apenas a alteração, nunca o valor
+var camposSensiveis = map[string]bool{"dados_bancarios": true}
+
+// camposPessoais são apagados do histórico na anonimização
+var camposPessoais = []string{"nome", "email", "telefone"}
+
+// valorOculto substitui no histórico o valor dos campos sensíveis
+const valorOculto = "[oculto]"
+
+// referenciaHistorico identifica o cargo ou o gestor no histórico pelo ID e
+// pelo nome da época
+type referenciaHistorico struct {
+	ID   int    `json:"id"`
+	Nome string `json:"nome"`
+}
+
+// dadosColaborador lê os campos acompanhados pelo histórico. Dentro de uma
+// transação, trava o colaborador até o fim dela.
+func dadosColaborador(db queryRower, id int) (map[string]interface{}, error) {
+	var nome, email, status, telefone, cargoNome string
+	var cargoID int
+	var gestorID sql.NullInt64
+	var gestorNome sql.NullString
+	var dataAdmissao time.Time
+	var dadosBancarios []byte
+
+	err := db.QueryRow(`
+		SELECT u.nome, u.email, u.cargo_id, COALESCE(c.nome, ''), u.gestor_id, g.nome,
+		       u.data_admissao, u.status, u.telefone, u.dados_bancarios
+		FROM usuarios u
+		LEFT JOIN cargos c ON u.cargo_id = c.id
+		LEFT JOIN usuarios g ON u.gestor_id = g.id
+		WHERE u.id = $1
+		FOR UPDATE OF u
+	`, id).Scan(&nome, &email, &cargoID, &cargoNome, &gestorID, &gestorNome, &dataAdmissao, &status, &telefone, &dadosBancarios)
+	if err == sql.ErrNoRows {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dados := map[string]interface{}{
+		"nome":            nome,
+		"email":           email,
+		"cargo":           referenciaHistorico{ID: cargoID, Nome: cargoNome},
+		"gestor":          nil,
+		"data_admissao":   dataAdmissao.Format("2006-01-02"),
+		"status":          status,
+		"telefone":        telefone,
+		"dados_bancarios": nil,
+	}
+	if gestorID.Valid {
+		dados["gestor"] = referenciaHistorico{ID: int(gestorID.Int64), Nome: gestorNome.String}
+	}
+	if dadosBancarios != nil {
+		// O envelope muda a cada gravação, então toda gravação aparece como
+		// alteração; o valor nunca vai para o histórico
+		dados["dados_bancarios"] = string(dadosBancarios)
+	}
+
+	return dados, nil
+}
+
+// registrarHistoricoColaborador grava os campos que mudaram entre antes e
+// depois (antes nulo registra o cadastro). Nada é gravado se nenhum campo
+// mudou. alteradoPor zero indica uma alteração do sistema.
+func registrarHistoricoColaborador(db execer, colaboradorID int, acao string, alteradoPor int, antes, depois map[string]interface{}) error {
+	campos := []string{}
+	anteriores := map[string]interface{}{}
+	novos := map[string]interface{}{}
+
+	for _, campo := range CamposHistoricoColaborador {
+		novo := depois[campo]
+		if antes == nil {
+			if novo == nil || novo == "" {
+				continue
+			}
+		} else if antes[campo] == novo {
+			continue
+		}
+
+		campos = append(campos, campo)
+		novos[campo] = ocultarValor(campo, novo)
+		if antes != nil {
+			anteriores[campo] = ocultarValor(campo, antes[campo])
+		}
+	}
+
+	if len(campos) == 0 {
+		return nil
+	}
+
+	dadosNovos, err := jsonOuNulo(novos)
+	if err != nil {
+		return err
+	}
+	var dadosAnteriores interface{}
+	if antes != nil {
+		if dadosAnteriores, err = jsonOuNulo(anteriores); err != nil {
+			return err
+		}
+	}
+
+	var autor interface{}
+	if alteradoPor > 0 {
+		autor = alteradoPor
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO historico_colaboradores (usuario_id, acao, campos, alterado_por, dados_anteriores, dados_novos)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, colaboradorID, acao, pq.Array(campos), autor, dadosAnteriores, dadosNovos)
+	return err
+}
+
+func ocultarValor(campo string, valor interface{}) interface{} {
+	if camposSensiveis[campo] && valor != nil {
+		return valorOculto
+	}
+	return valor
+}
+
+// alterarComHistorico executa a alteração em uma transação e registra no
+// histórico os campos do colaborador que ela mudou
+func (r *ColaboradorRepository) alterarComHistorico(id, alteradoPor int, alterar func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	antes, err := dadosColaborador(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := alterar(tx); err != nil {
+		return err
+	}
+
+	depois, err := dadosColaborador(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := registrarHistoricoColaborador(tx, id, AcaoColaboradorAtualizado, alteradoPor, antes, depois); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListHistorico retorna as alterações do colaborador, da mais recente para a
+// mais antiga. Com campo, apenas as que alteraram esse campo.
+func (r *ColaboradorRepository) ListHistorico(colaboradorID int, campo string, limit, offset int) ([]*model.HistoricoColaborador, error) {
+	where := "WHERE h.usuario_id = $1"
+	params := []interface{}{colaboradorID}
+	if campo != "" {
+		params = append(params, campo)
+		where += fmt.Sprintf(" AND $%d = ANY(h.campos)", len(params))
+	}
+	params = append(params, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT h.id, h.usuario_id, h.acao, h.campos, h.alterado_por, COALESCE(u.nome, ''),
+		       h.dados_anteriores, h.dados_novos, h.criado_em
+		FROM historico_colaboradores h
+		LEFT JOIN usuarios u ON h.alterado_por = u.id
+		%s
+		ORDER BY h.criado_em DESC, h.id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(params)-1, len(params))
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	historico := []*model.HistoricoColaborador{}
+	for rows.Next() {
+		item := &model.HistoricoColaborador{}
+		var anteriores, novos []byte
+
+		err := rows.Scan(
+			&item.ID,
+			&item.ColaboradorID,
+			&item.Acao,
+			pq.Array(&item.Campos),
+			&item.AlteradoPor,
+			&item.AlteradoPorNome,
+			&anteriores,
+			&novos,
+			&item.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if anteriores != nil {
+			item.DadosAnteriores = anteriores
+		}
+		if novos != nil {
+			item.DadosNovos = novos
+		}
+
+		historico = append(historico, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return historico, nil
+}
+
+// anonimizarHistoricoColaborador apaga do histórico os valores dos campos
+// pessoais, mantendo o registro de quando foram alterados. No histórico dos
+// subordinados, o nome do colaborador como gestor dá lugar ao pseudônimo.
+func anonimizarHistoricoColaborador(db execer, colaboradorID int, pseudonimo string) error {
+	_, err := db.Exec(`
+		UPDATE historico_colaboradores
+		SET dados_anteriores = dados_anteriores - $2::text[],
+		    dados_novos = dados_novos - $2::text[]
+		WHERE usuario_id = $1
+	`, colaboradorID, pq.Array(camposPessoais))
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		UPDATE historico_colaboradores
+		SET dados_anteriores = CASE WHEN dados_anteriores->'gestor'->>'id' = $1
+		                            THEN jsonb_set(dados_anteriores, '{gestor,nome}', to_jsonb($2::text))
+		                            ELSE dados_anteriores END,
+		    dados_novos = CASE WHEN dados_novos->'gestor'->>'id' = $1
+		                       THEN jsonb_set(dados_novos, '{gestor,nome}', to_jsonb($2::text))
+		                       ELSE dados_novos END
+		WHERE dados_anteriores->'gestor'->>'id' = $1
+		   OR dados_novos->'gestor'->>'id' = $1
+	`, strconv.Itoa(colaboradorID), pseudonimo)
+	return err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+type HistoricoSenhaRepository struct {
+	db *sql.DB
+}
+
+func NewHistoricoSenhaRepository(db *sql.DB) *HistoricoSenhaRepository {
+	return &HistoricoSenhaRepository{db: db}
+}
+
+// Create registra um hash de senha anterior e mantém apenas os `manter` mais recentes
+func (r *HistoricoSenhaRepository) Create(colaboradorID int, senhaHash string, manter int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO historico_senhas (usuario_id, senha_hash) VALUES ($1, $2)`, colaboradorID, senhaHash); err != nil {
+		return err
+	}
+
+	query := `
+		DELETE FROM historico_senhas
+		WHERE usuario_id = $1 AND id NOT IN (
+			SELECT id FROM historico_senhas
+			WHERE usuario_id = $1
+			ORDER BY criado_em DESC, id DESC
+			LIMIT $2
+		)
+	`
+	if _, err := tx.Exec(query, colaboradorID, manter); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListRecent retorna os hashes das últimas senhas do colaborador
+func (r *HistoricoSenhaRepository) ListRecent(colaboradorID, limit int) ([]string, error) {
+	query := `
+		SELECT senha_hash
+		FROM historico_senhas
+		WHERE usuario_id = $1
+		ORDER BY criado_em DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, colaboradorID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
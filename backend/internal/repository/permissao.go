@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"empresa-app/backend/internal/model"
+)
+
+type PermissaoRepository struct {
+	db *sql.DB
+}
+
+func NewPermissaoRepository(db *sql.DB) *PermissaoRepository {
+	return &PermissaoRepository{db: db}
+}
+
+func (r *PermissaoRepository) List() ([]*model.Permissao, error) {
+	query := `
+		SELECT id, codigo, descricao, criado_em
+		FROM permissoes
+		ORDER BY codigo ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissoes := []*model.Permissao{}
+	for rows.Next() {
+		permissao := &model.Permissao{}
+		if err := rows.Scan(&permissao.ID, &permissao.Codigo, &permissao.Descricao, &permissao.CriadoEm); err != nil {
+			return nil, err
+		}
+		permissoes = append(permissoes, permissao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissoes, nil
+}
+
+// ListByCargo retorna os códigos das permissões concedidas ao cargo
+func (r *PermissaoRepository) ListByCargo(cargoID int) ([]string, error) {
+	query := `
+		SELECT p.codigo
+		FROM cargo_permissoes cp
+		JOIN permissoes p ON cp.permissao_id = p.id
+		WHERE cp.cargo_id = $1
+		ORDER BY p.codigo ASC
+	`
+
+	rows, err := r.db.Query(query, cargoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codigos := []string{}
+	for rows.Next() {
+		var codigo string
+		if err := rows.Scan(&codigo); err != nil {
+			return nil, err
+		}
+		codigos = append(codigos, codigo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return codigos, nil
+}
+
+// ListGrants retorna todas as concessões agrupadas por cargo
+func (r *PermissaoRepository) ListGrants() (map[int][]string, error) {
+	query := `
+		SELECT cp.cargo_id, p.codigo
+		FROM cargo_permissoes cp
+		JOIN permissoes p ON cp.permissao_id = p.id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := map[int][]string{}
+	for rows.Next() {
+		var cargoID int
+		var codigo string
+		if err := rows.Scan(&cargoID, &codigo); err != nil {
+			return nil, err
+		}
+		grants[cargoID] = append(grants[cargoID], codigo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// Grant concede a permissão ao cargo
+func (r *PermissaoRepository) Grant(cargoID int, codigo string, concedidoPor int) error {
+	query := `
+		INSERT INTO cargo_permissoes (cargo_id, permissao_id, concedido_por)
+		SELECT $1, id, $3
+		FROM permissoes
+		WHERE codigo = $2
+		ON CONFLICT (cargo_id, permissao_id) DO NOTHING
+	`
+
+	result, err := r.db.Exec(query, cargoID, codigo, concedidoPor)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		// Permissão inexistente ou já concedida
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM permissoes WHERE codigo = $1)`, codigo).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return errors.New("permissão não encontrada")
+		}
+	}
+
+	return nil
+}
+
+// Revoke remove a permissão do cargo
+func (r *PermissaoRepository) Revoke(cargoID int, codigo string) error {
+	query := `
+		DELETE FROM cargo_permissoes
+		WHERE cargo_id = $1
+		  AND permissao_id = (SELECT id FROM permissoes WHERE codigo = $2)
+	`
+
+	result, err := r.db.Exec(query, cargoID, codigo)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("permissão não concedida a este cargo")
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type PersonificacaoRepository struct {
+	db *sql.DB
+}
+
+func NewPersonificacaoRepository(db *sql.DB) *PersonificacaoRepository {
+	return &PersonificacaoRepository{db: db}
+}
+
+func (r *PersonificacaoRepository) Create(personificacao *model.Personificacao) error {
+	query := `
+		INSERT INTO personificacoes (ator_id, alvo_id, motivo, jti, ip, expira_em)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		personificacao.AtorID,
+		personificacao.AlvoID,
+		personificacao.Motivo,
+		personificacao.JTI,
+		personificacao.IP,
+		personificacao.ExpiraEm,
+	).Scan(&personificacao.ID, &personificacao.CriadoEm)
+}
+
+func (r *PersonificacaoRepository) GetByID(id int) (*model.Personificacao, error) {
+	query := `
+		SELECT id, ator_id, alvo_id, motivo, jti, ip, criado_em, expira_em, encerrado_em
+		FROM personificacoes
+		WHERE id = $1
+	`
+
+	personificacao, err := scanPersonificacao(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("personificação não encontrada")
+		}
+		return nil, err
+	}
+
+	return personificacao, nil
+}
+
+// List retorna as personificações, das mais recentes para as mais antigas
+func (r *PersonificacaoRepository) List(limit, offset int) ([]*model.Personificacao, error) {
+	query := `
+		SELECT id, ator_id, alvo_id, motivo, jti, ip, criado_em, expira_em, encerrado_em
+		FROM personificacoes
+		ORDER BY criado_em DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	personificacoes := []*model.Personificacao{}
+	for rows.Next() {
+		personificacao, err := scanPersonificacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		personificacoes = append(personificacoes, personificacao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return personificacoes, nil
+}
+
+// End marca a personificação como encerrada
+func (r *PersonificacaoRepository) End(id int) error {
+	query := `
+		UPDATE personificacoes
+		SET encerrado_em = $1
+		WHERE id = $2 AND encerrado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("personificação não encontrada ou já encerrada")
+	}
+
+	return nil
+}
+
+// EndByAtor encerra as personificações em andamento iniciadas pelo
+// administrador e as retorna, para que seus tokens sejam revogados
+func (r *PersonificacaoRepository) EndByAtor(atorID int) ([]*model.Personificacao, error) {
+	query := `
+		UPDATE personificacoes
+		SET encerrado_em = $1
+		WHERE ator_id = $2 AND encerrado_em IS NULL AND expira_em > $1
+		RETURNING id, ator_id, alvo_id, motivo, jti, ip, criado_em, expira_em, encerrado_em
+	`
+
+	rows, err := r.db.Query(query, time.Now(), atorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	personificacoes := []*model.Personificacao{}
+	for rows.Next() {
+		personificacao, err := scanPersonificacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		personificacoes = append(personificacoes, personificacao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return personificacoes, nil
+}
+
+func (r *PersonificacaoRepository) CreateRequisicao(requisicao *model.RequisicaoPersonificacao) error {
+	query := `
+		INSERT INTO requisicoes_personificacao (personificacao_id, metodo, rota, caminho, status, ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		requisicao.PersonificacaoID,
+		requisicao.Metodo,
+		requisicao.Rota,
+		requisicao.Caminho,
+		requisicao.Status,
+		requisicao.IP,
+	).Scan(&requisicao.ID, &requisicao.CriadoEm)
+}
+
+// UpdateRequisicaoStatus registra o status HTTP da resposta
+func (r *PersonificacaoRepository) UpdateRequisicaoStatus(id, status int) error {
+	_, err := r.db.Exec(`UPDATE requisicoes_personificacao SET status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+// ListRequisicoes retorna as requisições feitas na personificação, em ordem cronológica
+func (r *PersonificacaoRepository) ListRequisicoes(personificacaoID int) ([]*model.RequisicaoPersonificacao, error) {
+	query := `
+		SELECT id, personificacao_id, metodo, rota, caminho, status, ip, criado_em
+		FROM requisicoes_personificacao
+		WHERE personificacao_id = $1
+		ORDER BY criado_em, id
+	`
+
+	rows, err := r.db.Query(query, personificacaoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requisicoes := []*model.RequisicaoPersonificacao{}
+	for rows.Next() {
+		requisicao := &model.RequisicaoPersonificacao{}
+		err := rows.Scan(
+			&requisicao.ID,
+			&requisicao.PersonificacaoID,
+			&requisicao.Metodo,
+			&requisicao.Rota,
+			&requisicao.Caminho,
+			&requisicao.Status,
+			&requisicao.IP,
+			&requisicao.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		requisicoes = append(requisicoes, requisicao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requisicoes, nil
+}
+
+func scanPersonificacao(row interface{ Scan(...interface{}) error }) (*model.Personificacao, error) {
+	personificacao := &model.Personificacao{}
+	var encerradoEm sql.NullTime
+
+	err := row.Scan(
+		&personificacao.ID,
+		&personificacao.AtorID,
+		&personificacao.AlvoID,
+		&personificacao.Motivo,
+		&personificacao.JTI,
+		&personificacao.IP,
+		&personificacao.CriadoEm,
+		&personificacao.ExpiraEm,
+		&encerradoEm,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if encerradoEm.Valid {
+		personificacao.EncerradoEm = &encerradoEm.Time
+	}
+
+	return personificacao, nil
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"database/sql"
-	"time"
+	"fmt"
 
 	"empresa-app/backend/internal/model"
 )
@@ -52,25 +52,60 @@ func (r *PontoRepository) Registrar(ponto *model.RegistroPonto) error {
 	return nil
 }
 
-func (r *PontoRepository) Listar(colaboradorID int, data time.Time) ([]*model.RegistroPonto, error) {
+// Listar retorna os registros do dia, de um colaborador ou, sem colaborador
+// no filtro, de todos os alocados no departamento e/ou centro de custo
+func (r *PontoRepository) Listar(filtro model.PontoFiltro) ([]*model.RegistroPonto, error) {
 	// Obter data no formato YYYY-MM-DD
-	dataFormatada := data.Format("2006-01-02")
+	dataFormatada := filtro.Data.Format("2006-01-02")
 
 	query := `
 		SELECT 
 			id, usuario_id, tipo, data_hora, localizacao, ip_origem, 
 			dispositivo, observacao, criado_em
 		FROM registros_ponto
-		WHERE usuario_id = $1 AND DATE(data_hora) = $2
+		WHERE DATE(data_hora) = $1
+	`
+	params := []interface{}{dataFormatada}
+
+	if filtro.ColaboradorID != nil {
+		params = append(params, *filtro.ColaboradorID)
+		query += fmt.Sprintf(" AND usuario_id = $%d", len(params))
+	}
+
+	condicao, params := filtroAlocacao("usuario_id", "DATE(data_hora)", filtro.DepartamentoID, filtro.CentroCustoID, params)
+	query += condicao + " ORDER BY usuario_id, data_hora ASC"
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRegistrosPonto(rows)
+}
+
+// ListByColaborador retorna todos os registros do colaborador, em ordem
+// cronológica
+func (r *PontoRepository) ListByColaborador(colaboradorID int) ([]*model.RegistroPonto, error) {
+	query := `
+		SELECT
+			id, usuario_id, tipo, data_hora, localizacao, ip_origem,
+			dispositivo, observacao, criado_em
+		FROM registros_ponto
+		WHERE usuario_id = $1
 		ORDER BY data_hora ASC
 	`
 
-	rows, err := r.db.Query(query, colaboradorID, dataFormatada)
+	rows, err := r.db.Query(query, colaboradorID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanRegistrosPonto(rows)
+}
+
+func scanRegistrosPonto(rows *sql.Rows) ([]*model.RegistroPonto, error) {
 	pontos := []*model.RegistroPonto{}
 	for rows.Next() {
 		ponto := &model.RegistroPonto{}
@@ -95,7 +130,7 @@ func (r *PontoRepository) Listar(colaboradorID int, data time.Time) ([]*model.Re
 		pontos = append(pontos, ponto)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
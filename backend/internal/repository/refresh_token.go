@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (usuario_id, token_hash, familia, expira_em)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		token.ColaboradorID,
+		token.TokenHash,
+		token.Familia,
+		token.ExpiraEm,
+	).Scan(&token.ID, &token.CriadoEm)
+}
+
+func (r *RefreshTokenRepository) GetByHash(tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, usuario_id, token_hash, familia, expira_em, usado_em,
+		       revogado_em, substituido_por, criado_em
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	token := &model.RefreshToken{}
+	var usadoEm, revogadoEm sql.NullTime
+	var substituidoPor sql.NullInt32
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.ColaboradorID,
+		&token.TokenHash,
+		&token.Familia,
+		&token.ExpiraEm,
+		&usadoEm,
+		&revogadoEm,
+		&substituidoPor,
+		&token.CriadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("refresh token não encontrado")
+		}
+		return nil, err
+	}
+
+	// Converter nullable fields
+	if usadoEm.Valid {
+		token.UsadoEm = &usadoEm.Time
+	}
+
+	if revogadoEm.Valid {
+		token.RevogadoEm = &revogadoEm.Time
+	}
+
+	if substituidoPor.Valid {
+		val := int(substituidoPor.Int32)
+		token.SubstituidoPor = &val
+	}
+
+	return token, nil
+}
+
+// MarkAsUsed consome o token de forma atômica. Retorna false se o token já
+// tinha sido usado ou revogado (o que indica reuso).
+func (r *RefreshTokenRepository) MarkAsUsed(id int) (bool, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET usado_em = $1
+		WHERE id = $2 AND usado_em IS NULL AND revogado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// SetReplacedBy registra qual token substituiu o token consumido
+func (r *RefreshTokenRepository) SetReplacedBy(id, substitutoID int) error {
+	query := `
+		UPDATE refresh_tokens
+		SET substituido_por = $1
+		WHERE id = $2
+	`
+
+	_, err := r.db.Exec(query, substitutoID, id)
+	return err
+}
+
+// RevokeFamily revoga todos os tokens ainda ativos de uma família de rotação
+func (r *RefreshTokenRepository) RevokeFamily(familia string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revogado_em = $1
+		WHERE familia = $2 AND revogado_em IS NULL
+	`
+
+	_, err := r.db.Exec(query, time.Now(), familia)
+	return err
+}
+
+// RevokeByColaborador revoga todos os refresh tokens ativos de um colaborador
+func (r *RefreshTokenRepository) RevokeByColaborador(colaboradorID int) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revogado_em = $1
+		WHERE usuario_id = $2 AND revogado_em IS NULL
+	`
+
+	_, err := r.db.Exec(query, time.Now(), colaboradorID)
+	return err
+}
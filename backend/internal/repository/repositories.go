@@ -1,7 +1,27 @@
 package repository
 
 type Repositories struct {
-	Colaborador *ColaboradorRepository
-	Documento   *DocumentoRepository
-	Ponto       *PontoRepository
+	Colaborador             *ColaboradorRepository
+	Documento               *DocumentoRepository
+	Ponto                   *PontoRepository
+	RefreshToken            *RefreshTokenRepository
+	TokenRevogado           *TokenRevogadoRepository
+	Cargo                   *CargoRepository
+	Permissao               *PermissaoRepository
+	TokenRedefinicaoSenha   *TokenRedefinicaoSenhaRepository
+	DoisFatores             *DoisFatoresRepository
+	TentativaLogin          *TentativaLoginRepository
+	ContaServico            *ContaServicoRepository
+	Sessao                  *SessaoRepository
+	HistoricoSenha          *HistoricoSenhaRepository
+	Personificacao          *PersonificacaoRepository
+	AutorizacaoOIDC         *AutorizacaoOIDCRepository
+	FotoPerfil              *FotoPerfilRepository
+	RevelacaoDadosBancarios *RevelacaoDadosBancariosRepository
+	Departamento            *DepartamentoRepository
+	CentroCusto             *CentroCustoRepository
+	Alocacao                *AlocacaoRepository
+	Desligamento            *DesligamentoRepository
+	ExportacaoDados         *ExportacaoDadosRepository
+	Anonimizacao            *AnonimizacaoRepository
 }
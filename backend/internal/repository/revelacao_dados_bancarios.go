@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql"
+
+	"empresa-app/backend/internal/model"
+)
+
+type RevelacaoDadosBancariosRepository struct {
+	db *sql.DB
+}
+
+func NewRevelacaoDadosBancariosRepository(db *sql.DB) *RevelacaoDadosBancariosRepository {
+	return &RevelacaoDadosBancariosRepository{db: db}
+}
+
+// Create registra a consulta dos dados bancários completos
+func (r *RevelacaoDadosBancariosRepository) Create(revelacao *model.RevelacaoDadosBancarios) error {
+	query := `
+		INSERT INTO revelacoes_dados_bancarios (colaborador_id, revelado_por, motivo, ip)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		revelacao.ColaboradorID,
+		revelacao.ReveladoPor,
+		revelacao.Motivo,
+		revelacao.IP,
+	).Scan(&revelacao.ID, &revelacao.CriadoEm)
+}
+
+// ListByColaborador retorna as consultas aos dados de um colaborador, das
+// mais recentes para as mais antigas
+func (r *RevelacaoDadosBancariosRepository) ListByColaborador(colaboradorID, limit, offset int) ([]*model.RevelacaoDadosBancarios, error) {
+	query := `
+		SELECT r.id, r.colaborador_id, r.revelado_por, COALESCE(u.nome, ''), r.motivo, r.ip, r.criado_em
+		FROM revelacoes_dados_bancarios r
+		LEFT JOIN usuarios u ON r.revelado_por = u.id
+		WHERE r.colaborador_id = $1
+		ORDER BY r.criado_em DESC, r.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, colaboradorID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revelacoes := []*model.RevelacaoDadosBancarios{}
+	for rows.Next() {
+		revelacao := &model.RevelacaoDadosBancarios{}
+		err := rows.Scan(
+			&revelacao.ID,
+			&revelacao.ColaboradorID,
+			&revelacao.ReveladoPor,
+			&revelacao.ReveladoPorNome,
+			&revelacao.Motivo,
+			&revelacao.IP,
+			&revelacao.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		revelacoes = append(revelacoes, revelacao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revelacoes, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+// ErrSessaoNaoEncontrada indica que não há sessão aberta com o ID informado
+// para o colaborador
+var ErrSessaoNaoEncontrada = errors.New("sessão não encontrada")
+
+type SessaoRepository struct {
+	db *sql.DB
+}
+
+func NewSessaoRepository(db *sql.DB) *SessaoRepository {
+	return &SessaoRepository{db: db}
+}
+
+// Upsert cria a sessão da família ou, se ela já existir, atualiza o último
+// acesso, o IP e a validade. Sessões encerradas não são reabertas.
+func (r *SessaoRepository) Upsert(sessao *model.Sessao) error {
+	query := `
+		INSERT INTO sessoes (usuario_id, familia, dispositivo, user_agent, ip, expira_em)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (familia) DO UPDATE
+		SET ultimo_acesso_em = CURRENT_TIMESTAMP, ip = EXCLUDED.ip, expira_em = EXCLUDED.expira_em
+		WHERE sessoes.encerrado_em IS NULL
+		RETURNING id, dispositivo, user_agent, criado_em, ultimo_acesso_em
+	`
+
+	err := r.db.QueryRow(
+		query,
+		sessao.ColaboradorID,
+		sessao.Familia,
+		sessao.Dispositivo,
+		sessao.UserAgent,
+		sessao.IP,
+		sessao.ExpiraEm,
+	).Scan(&sessao.ID, &sessao.Dispositivo, &sessao.UserAgent, &sessao.CriadoEm, &sessao.UltimoAcessoEm)
+
+	if err == sql.ErrNoRows {
+		return errors.New("sessão encerrada")
+	}
+	return err
+}
+
+// ListActive retorna as sessões não encerradas e não expiradas do colaborador
+func (r *SessaoRepository) ListActive(colaboradorID int, now time.Time) ([]*model.Sessao, error) {
+	query := `
+		SELECT id, usuario_id, familia, dispositivo, user_agent, ip,
+		       criado_em, ultimo_acesso_em, expira_em
+		FROM sessoes
+		WHERE usuario_id = $1 AND encerrado_em IS NULL AND expira_em > $2
+		ORDER BY ultimo_acesso_em DESC
+	`
+
+	rows, err := r.db.Query(query, colaboradorID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessoes := []*model.Sessao{}
+	for rows.Next() {
+		sessao := &model.Sessao{}
+		err := rows.Scan(
+			&sessao.ID,
+			&sessao.ColaboradorID,
+			&sessao.Familia,
+			&sessao.Dispositivo,
+			&sessao.UserAgent,
+			&sessao.IP,
+			&sessao.CriadoEm,
+			&sessao.UltimoAcessoEm,
+			&sessao.ExpiraEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sessoes = append(sessoes, sessao)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessoes, nil
+}
+
+// End encerra a sessão do colaborador e retorna a família de refresh tokens
+func (r *SessaoRepository) End(id, colaboradorID int) (string, error) {
+	query := `
+		UPDATE sessoes
+		SET encerrado_em = $1
+		WHERE id = $2 AND usuario_id = $3 AND encerrado_em IS NULL
+		RETURNING familia
+	`
+
+	var familia string
+	err := r.db.QueryRow(query, time.Now(), id, colaboradorID).Scan(&familia)
+	if err == sql.ErrNoRows {
+		return "", ErrSessaoNaoEncontrada
+	}
+	return familia, err
+}
+
+// EndByFamilia encerra a sessão ligada à família de refresh tokens e retorna
+// seu ID. Retorna 0 se não houver sessão aberta para a família.
+func (r *SessaoRepository) EndByFamilia(familia string) (int, error) {
+	query := `
+		UPDATE sessoes
+		SET encerrado_em = $1
+		WHERE familia = $2 AND encerrado_em IS NULL
+		RETURNING id
+	`
+
+	var id int
+	err := r.db.QueryRow(query, time.Now(), familia).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return id, err
+}
+
+// EndAllByColaborador encerra todas as sessões abertas do colaborador
+func (r *SessaoRepository) EndAllByColaborador(colaboradorID int) error {
+	query := `
+		UPDATE sessoes
+		SET encerrado_em = $1
+		WHERE usuario_id = $2 AND encerrado_em IS NULL
+	`
+
+	_, err := r.db.Exec(query, time.Now(), colaboradorID)
+	return err
+}
+
+// Touch registra o último acesso da sessão
+func (r *SessaoRepository) Touch(id int, ip string) error {
+	query := `
+		UPDATE sessoes
+		SET ultimo_acesso_em = CURRENT_TIMESTAMP, ip = $1
+		WHERE id = $2 AND encerrado_em IS NULL
+	`
+
+	_, err := r.db.Exec(query, ip, id)
+	return err
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+// ErrTentativaNaoEncontrada indica que não há falhas registradas para a chave
+var ErrTentativaNaoEncontrada = errors.New("nenhuma tentativa registrada")
+
+type TentativaLoginRepository struct {
+	db *sql.DB
+}
+
+func NewTentativaLoginRepository(db *sql.DB) *TentativaLoginRepository {
+	return &TentativaLoginRepository{db: db}
+}
+
+func (r *TentativaLoginRepository) Get(chave string) (*model.TentativaLogin, error) {
+	query := `
+		SELECT chave, falhas, ultima_falha, bloqueado_ate
+		FROM tentativas_login
+		WHERE chave = $1
+	`
+
+	tentativa := &model.TentativaLogin{}
+	var bloqueadoAte sql.NullTime
+
+	err := r.db.QueryRow(query, chave).Scan(
+		&tentativa.Chave,
+		&tentativa.Falhas,
+		&tentativa.UltimaFalha,
+		&bloqueadoAte,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTentativaNaoEncontrada
+		}
+		return nil, err
+	}
+
+	if bloqueadoAte.Valid {
+		tentativa.BloqueadoAte = &bloqueadoAte.Time
+	}
+
+	return tentativa, nil
+}
+
+// Increment soma uma falha ao contador de forma atômica e retorna o total. Se
+// a primeira falha contada for anterior a inicioJanela, a contagem recomeça.
+func (r *TentativaLoginRepository) Increment(chave string, now, inicioJanela time.Time) (int, error) {
+	query := `
+		INSERT INTO tentativas_login (chave, falhas, primeira_falha, ultima_falha)
+		VALUES ($1, 1, $2, $2)
+		ON CONFLICT (chave) DO UPDATE
+		SET falhas = CASE WHEN tentativas_login.primeira_falha < $3 THEN 1 ELSE tentativas_login.falhas + 1 END,
+		    primeira_falha = CASE WHEN tentativas_login.primeira_falha < $3 THEN EXCLUDED.primeira_falha ELSE tentativas_login.primeira_falha END,
+		    ultima_falha = EXCLUDED.ultima_falha
+		RETURNING falhas
+	`
+
+	var falhas int
+	err := r.db.QueryRow(query, chave, now, inicioJanela).Scan(&falhas)
+	return falhas, err
+}
+
+// Decrement desconta uma falha do contador, sem alterar um bloqueio em vigor
+func (r *TentativaLoginRepository) Decrement(chave string) error {
+	query := `
+		UPDATE tentativas_login
+		SET falhas = falhas - 1
+		WHERE chave = $1 AND falhas > 0
+	`
+
+	_, err := r.db.Exec(query, chave)
+	return err
+}
+
+// Lock bloqueia a chave até o instante informado e zera o contador
+func (r *TentativaLoginRepository) Lock(chave string, ate time.Time) error {
+	query := `
+		UPDATE tentativas_login
+		SET falhas = 0, bloqueado_ate = $1
+		WHERE chave = $2
+	`
+
+	_, err := r.db.Exec(query, ate, chave)
+	return err
+}
+
+// Reset remove o contador e o bloqueio da chave
+func (r *TentativaLoginRepository) Reset(chave string) error {
+	_, err := r.db.Exec(`DELETE FROM tentativas_login WHERE chave = $1`, chave)
+	return err
+}
+
+// CreateHistorico registra uma tentativa de login
+func (r *TentativaLoginRepository) CreateHistorico(historico *model.HistoricoLogin) error {
+	query := `
+		INSERT INTO historico_login (usuario_id, email, ip, user_agent, resultado)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(
+		query,
+		historico.ColaboradorID,
+		historico.Email,
+		historico.IP,
+		historico.UserAgent,
+		historico.Resultado,
+	).Scan(&historico.ID, &historico.CriadoEm)
+}
+
+// ListHistorico retorna o histórico de login do colaborador, do mais recente
+// para o mais antigo
+func (r *TentativaLoginRepository) ListHistorico(colaboradorID, limit, offset int) ([]*model.HistoricoLogin, error) {
+	query := `
+		SELECT id, usuario_id, email, ip, user_agent, resultado, criado_em
+		FROM historico_login
+		WHERE usuario_id = $1
+		ORDER BY criado_em DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, colaboradorID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	historico := []*model.HistoricoLogin{}
+	for rows.Next() {
+		item := &model.HistoricoLogin{}
+		var usuarioID sql.NullInt32
+
+		err := rows.Scan(
+			&item.ID,
+			&usuarioID,
+			&item.Email,
+			&item.IP,
+			&item.UserAgent,
+			&item.Resultado,
+			&item.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if usuarioID.Valid {
+			val := int(usuarioID.Int32)
+			item.ColaboradorID = &val
+		}
+
+		historico = append(historico, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return historico, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type TokenRedefinicaoSenhaRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRedefinicaoSenhaRepository(db *sql.DB) *TokenRedefinicaoSenhaRepository {
+	return &TokenRedefinicaoSenhaRepository{db: db}
+}
+
+func (r *TokenRedefinicaoSenhaRepository) Create(token *model.TokenRedefinicaoSenha) error {
+	query := `
+		INSERT INTO tokens_redefinicao_senha (usuario_id, token_hash, expira_em)
+		VALUES ($1, $2, $3)
+		RETURNING id, criado_em
+	`
+
+	return r.db.QueryRow(query, token.ColaboradorID, token.TokenHash, token.ExpiraEm).
+		Scan(&token.ID, &token.CriadoEm)
+}
+
+func (r *TokenRedefinicaoSenhaRepository) GetByHash(tokenHash string) (*model.TokenRedefinicaoSenha, error) {
+	query := `
+		SELECT id, usuario_id, token_hash, expira_em, usado_em, criado_em
+		FROM tokens_redefinicao_senha
+		WHERE token_hash = $1
+	`
+
+	token := &model.TokenRedefinicaoSenha{}
+	var usadoEm sql.NullTime
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.ColaboradorID,
+		&token.TokenHash,
+		&token.ExpiraEm,
+		&usadoEm,
+		&token.CriadoEm,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("token não encontrado")
+		}
+		return nil, err
+	}
+
+	if usadoEm.Valid {
+		token.UsadoEm = &usadoEm.Time
+	}
+
+	return token, nil
+}
+
+// MarkAsUsed consome o token de forma atômica. Retorna false se o token já
+// tinha sido usado.
+func (r *TokenRedefinicaoSenhaRepository) MarkAsUsed(id int) (bool, error) {
+	query := `
+		UPDATE tokens_redefinicao_senha
+		SET usado_em = $1
+		WHERE id = $2 AND usado_em IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// InvalidateByColaborador consome todos os tokens pendentes do colaborador
+func (r *TokenRedefinicaoSenhaRepository) InvalidateByColaborador(colaboradorID int) error {
+	return invalidarTokensRedefinicao(r.db, colaboradorID)
+}
+
+// invalidarTokensRedefinicao consome os tokens pendentes (redefinição e
+// convite) do colaborador
+func invalidarTokensRedefinicao(db execer, colaboradorID int) error {
+	query := `
+		UPDATE tokens_redefinicao_senha
+		SET usado_em = $1
+		WHERE usuario_id = $2 AND usado_em IS NULL
+	`
+
+	_, err := db.Exec(query, time.Now(), colaboradorID)
+	return err
+}
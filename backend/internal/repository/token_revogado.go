@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"empresa-app/backend/internal/model"
+)
+
+type TokenRevogadoRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRevogadoRepository(db *sql.DB) *TokenRevogadoRepository {
+	return &TokenRevogadoRepository{db: db}
+}
+
+// Revoke adiciona um jti à lista de revogação
+func (r *TokenRevogadoRepository) Revoke(token *model.TokenRevogado) error {
+	query := `
+		INSERT INTO tokens_revogados (jti, usuario_id, expira_em)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query, token.JTI, token.ColaboradorID, token.ExpiraEm)
+	return err
+}
+
+// Consume adiciona o jti à lista de revogação apenas se ele ainda não estiver
+// nela, informando se foi esta chamada que o adicionou (tokens de uso único)
+func (r *TokenRevogadoRepository) Consume(token *model.TokenRevogado) (bool, error) {
+	query := `
+		INSERT INTO tokens_revogados (jti, usuario_id, expira_em)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	result, err := r.db.Exec(query, token.JTI, token.ColaboradorID, token.ExpiraEm)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := linhasAfetadas(result)
+	return n == 1, err
+}
+
+// RevokeAllByColaborador invalida todos os tokens do colaborador emitidos até
+// revogadoEm. A entrada pode ser descartada após expiraEm.
+func (r *TokenRevogadoRepository) RevokeAllByColaborador(colaboradorID int, revogadoEm, expiraEm time.Time) error {
+	query := `
+		INSERT INTO revogacoes_colaborador (usuario_id, revogado_em, expira_em)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (usuario_id) DO UPDATE
+		SET revogado_em = EXCLUDED.revogado_em, expira_em = EXCLUDED.expira_em
+	`
+
+	_, err := r.db.Exec(query, colaboradorID, revogadoEm, expiraEm)
+	return err
+}
+
+// ListActive retorna os jtis revogados que ainda não expiraram
+func (r *TokenRevogadoRepository) ListActive(now time.Time) ([]*model.TokenRevogado, error) {
+	query := `
+		SELECT jti, usuario_id, expira_em, revogado_em
+		FROM tokens_revogados
+		WHERE expira_em > $1
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*model.TokenRevogado{}
+	for rows.Next() {
+		token := &model.TokenRevogado{}
+		if err := rows.Scan(&token.JTI, &token.ColaboradorID, &token.ExpiraEm, &token.RevogadoEm); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// ListActiveColaboradores retorna, por colaborador, o instante até o qual os
+// tokens emitidos estão revogados
+func (r *TokenRevogadoRepository) ListActiveColaboradores(now time.Time) (map[int]time.Time, error) {
+	query := `
+		SELECT usuario_id, revogado_em
+		FROM revogacoes_colaborador
+		WHERE expira_em > $1
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revogacoes := map[int]time.Time{}
+	for rows.Next() {
+		var colaboradorID int
+		var revogadoEm time.Time
+		if err := rows.Scan(&colaboradorID, &revogadoEm); err != nil {
+			return nil, err
+		}
+		revogacoes[colaboradorID] = revogadoEm
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revogacoes, nil
+}
+
+// RevokeSessao invalida os tokens de acesso emitidos para a sessão. A entrada
+// pode ser descartada após expiraEm.
+func (r *TokenRevogadoRepository) RevokeSessao(sessaoID int, expiraEm time.Time) error {
+	query := `
+		INSERT INTO revogacoes_sessao (sessao_id, expira_em)
+		VALUES ($1, $2)
+		ON CONFLICT (sessao_id) DO UPDATE
+		SET expira_em = EXCLUDED.expira_em
+	`
+
+	_, err := r.db.Exec(query, sessaoID, expiraEm)
+	return err
+}
+
+// ListActiveSessoes retorna as sessões cujos tokens de acesso estão revogados
+func (r *TokenRevogadoRepository) ListActiveSessoes(now time.Time) (map[int]time.Time, error) {
+	query := `
+		SELECT sessao_id, expira_em
+		FROM revogacoes_sessao
+		WHERE expira_em > $1
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessoes := map[int]time.Time{}
+	for rows.Next() {
+		var sessaoID int
+		var expiraEm time.Time
+		if err := rows.Scan(&sessaoID, &expiraEm); err != nil {
+			return nil, err
+		}
+		sessoes[sessaoID] = expiraEm
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessoes, nil
+}
+
+// DeleteExpired remove entradas cujos tokens já expiraram naturalmente
+func (r *TokenRevogadoRepository) DeleteExpired(now time.Time) error {
+	if _, err := r.db.Exec(`DELETE FROM tokens_revogados WHERE expira_em <= $1`, now); err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM revogacoes_colaborador WHERE expira_em <= $1`, now); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`DELETE FROM revogacoes_sessao WHERE expira_em <= $1`, now)
+	return err
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+var (
+	ErrAlocacaoNaoEncontrada = errors.New("alocação não encontrada")
+	ErrAlocacaoVazia         = errors.New("informe o departamento, o centro de custo ou ambos")
+	ErrAlocacaoAntesAdmissao = errors.New("a vigência não pode começar antes da admissão do colaborador")
+	ErrVigenciaInvalida      = errors.New("data de início da vigência inválida: use AAAA-MM-DD")
+	ErrDepartamentoInativo   = errors.New("departamento inativo não recebe novas alocações")
+	ErrCentroCustoInativo    = errors.New("centro de custo inativo não recebe novas alocações")
+)
+
+// AlocacaoService aloca colaboradores em departamentos e centros de custo com
+// data de vigência. Alterações retroativas são permitidas para correções
+// contábeis; elas mudam a atribuição dos documentos e pontos do período.
+type AlocacaoService struct {
+	alocacaoRepo     *repository.AlocacaoRepository
+	colaboradorRepo  *repository.ColaboradorRepository
+	departamentoRepo *repository.DepartamentoRepository
+	centroCustoRepo  *repository.CentroCustoRepository
+}
+
+func NewAlocacaoService(
+	alocacaoRepo *repository.AlocacaoRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	departamentoRepo *repository.DepartamentoRepository,
+	centroCustoRepo *repository.CentroCustoRepository,
+) *AlocacaoService {
+	return &AlocacaoService{
+		alocacaoRepo:     alocacaoRepo,
+		colaboradorRepo:  colaboradorRepo,
+		departamentoRepo: departamentoRepo,
+		centroCustoRepo:  centroCustoRepo,
+	}
+}
+
+// List retorna o histórico de alocações do colaborador
+func (s *AlocacaoService) List(colaboradorID int) ([]*model.AlocacaoColaborador, error) {
+	if _, err := s.colaboradorRepo.GetByID(colaboradorID); err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	return s.alocacaoRepo.ListByColaborador(colaboradorID)
+}
+
+// Alocar registra a alocação a partir da data de vigência. Apenas
+// departamentos e centros de custo ativos podem ser usados.
+func (s *AlocacaoService) Alocar(colaboradorID int, req model.AlocacaoRequest, criadoPor int) (*model.AlocacaoColaborador, error) {
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+
+	inicio, err := time.Parse("2006-01-02", req.VigenciaInicio)
+	if err != nil {
+		return nil, ErrVigenciaInvalida
+	}
+	if !colaborador.DataAdmissao.IsZero() && inicio.Before(truncarDia(colaborador.DataAdmissao)) {
+		return nil, ErrAlocacaoAntesAdmissao
+	}
+
+	if req.DepartamentoID == nil && req.CentroCustoID == nil {
+		return nil, ErrAlocacaoVazia
+	}
+
+	alocacao := &model.AlocacaoColaborador{
+		ColaboradorID:  colaboradorID,
+		DepartamentoID: req.DepartamentoID,
+		CentroCustoID:  req.CentroCustoID,
+		VigenciaInicio: inicio,
+	}
+	if criadoPor != 0 {
+		alocacao.CriadoPor = &criadoPor
+	}
+
+	if req.DepartamentoID != nil {
+		departamento, err := s.departamentoRepo.GetByID(*req.DepartamentoID)
+		if err != nil {
+			return nil, ErrDepartamentoNaoEncontrado
+		}
+		if !departamento.Ativo {
+			return nil, ErrDepartamentoInativo
+		}
+		alocacao.DepartamentoNome = departamento.Nome
+	}
+
+	if req.CentroCustoID != nil {
+		centro, err := s.centroCustoRepo.GetByID(*req.CentroCustoID)
+		if err != nil {
+			return nil, ErrCentroCustoNaoEncontrado
+		}
+		if !centro.Ativo {
+			return nil, ErrCentroCustoInativo
+		}
+		alocacao.CentroCustoNome = centro.Nome
+	}
+
+	if err := s.alocacaoRepo.Alocar(alocacao); err != nil {
+		return nil, err
+	}
+
+	return alocacao, nil
+}
+
+// Remover exclui uma alocação registrada por engano; a anterior passa a
+// cobrir o período dela
+func (s *AlocacaoService) Remover(colaboradorID, id int) error {
+	removida, err := s.alocacaoRepo.Remover(colaboradorID, id)
+	if err != nil {
+		return err
+	}
+	if !removida {
+		return ErrAlocacaoNaoEncontrada
+	}
+	return nil
+}
+
+// truncarDia descarta o horário e o fuso, para comparar com datas sem horário
+func truncarDia(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
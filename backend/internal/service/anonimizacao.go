@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+const maxAnonimizacoesPorPagina = 100
+
+// AnonimizacaoService apaga os dados pessoais dos ex-colaboradores cujo prazo
+// de retenção após o desligamento terminou (LGPD), mantendo os registros
+// financeiros e de ponto sob um pseudônimo
+type AnonimizacaoService struct {
+	anonimizacaoRepo *repository.AnonimizacaoRepository
+	retencaoDias     int
+
+	// Uma execução por vez nesta instância; entre instâncias, a transação de
+	// cada colaborador confere de novo se ele ainda é elegível
+	mu sync.Mutex
+}
+
+func NewAnonimizacaoService(anonimizacaoRepo *repository.AnonimizacaoRepository, retencaoDias int) *AnonimizacaoService {
+	return &AnonimizacaoService{
+		anonimizacaoRepo: anonimizacaoRepo,
+		retencaoDias:     retencaoDias,
+	}
+}
+
+// Executar anonimiza os desligados há mais tempo que o prazo de retenção. Na
+// simulação, apenas retorna o relatório do que seria apagado. Falhas em um
+// colaborador não interrompem os demais e ficam no relatório. realizadoPor
+// é nulo na rotina agendada.
+func (s *AnonimizacaoService) Executar(simular bool, realizadoPor *int) (*model.RelatorioAnonimizacao, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limite := truncarDia(time.Now()).AddDate(0, 0, -s.retencaoDias)
+
+	elegiveis, err := s.anonimizacaoRepo.ListElegiveis(limite)
+	if err != nil {
+		return nil, err
+	}
+
+	relatorio := &model.RelatorioAnonimizacao{
+		Simulacao:     simular,
+		DataLimite:    limite.Format("2006-01-02"),
+		Colaboradores: elegiveis,
+	}
+	if simular {
+		return relatorio, nil
+	}
+
+	for _, item := range elegiveis {
+		id := item.ColaboradorID
+		pseudonimo, err := gerarPseudonimo()
+		if err != nil {
+			return nil, err
+		}
+
+		anonimizacao := &model.Anonimizacao{
+			ColaboradorID: &id,
+			Pseudonimo:    pseudonimo,
+			RealizadoPor:  realizadoPor,
+		}
+		email := strings.ToLower(pseudonimo) + "@anonimizado.invalid"
+
+		arquivos, ok, err := s.anonimizacaoRepo.Anonimizar(anonimizacao, pseudonimo, email, uuid.New().String(), limite)
+		if err != nil {
+			log.Printf("Erro ao anonimizar o colaborador %d: %v", id, err)
+			item.Erro = "erro ao anonimizar; será tentado novamente na próxima execução"
+			relatorio.Falhas++
+			continue
+		}
+		if !ok {
+			item.Erro = "deixou de ser elegível durante a execução"
+			continue
+		}
+
+		item.Pseudonimo = pseudonimo
+		item.Resumo = anonimizacao.Resumo
+		relatorio.Anonimizados++
+
+		for _, caminho := range arquivos {
+			if err := os.Remove(caminho); err != nil && !os.IsNotExist(err) {
+				log.Printf("Erro ao apagar arquivo do colaborador anonimizado %s: %v", pseudonimo, err)
+			}
+		}
+	}
+
+	return relatorio, nil
+}
+
+// List retorna o registro das anonimizações realizadas
+func (s *AnonimizacaoService) List(limit, offset int) ([]*model.Anonimizacao, error) {
+	if limit <= 0 || limit > maxAnonimizacoesPorPagina {
+		limit = maxAnonimizacoesPorPagina
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.anonimizacaoRepo.List(limit, offset)
+}
+
+// gerarPseudonimo retorna um identificador aleatório, sem relação com o
+// colaborador, como ANON-3F9A2C1B7D
+func gerarPseudonimo() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "ANON-" + strings.ToUpper(hex.EncodeToString(b)), nil
+}
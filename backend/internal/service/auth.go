@@ -1,72 +1,537 @@
 package service
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"os"
+	"log"
+	"math"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"empresa-app/backend/internal/model"
 	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/config"
+	"empresa-app/backend/pkg/jwtkeys"
+)
+
+var (
+	ErrCredenciaisInvalidas = errors.New("credenciais inválidas")
+	ErrRefreshTokenInvalido = errors.New("refresh token inválido")
+	ErrRefreshTokenExpirado = errors.New("refresh token expirado")
+	ErrRefreshTokenReusado  = errors.New("refresh token já utilizado; sessão revogada")
+	ErrTokenRevogado        = errors.New("token revogado")
+	ErrDesafioInvalido      = errors.New("desafio de 2FA inválido ou expirado")
+)
+
+// Validade do desafio emitido entre a senha e o segundo fator
+const desafio2FAExpiration = 5 * time.Minute
+
+// Tipos (cabeçalho typ) e audiências dos tokens assinados. Só os tokens de
+// acesso têm a audiência da API: o desafio de 2FA usa outra, para que nem a
+// API nem verificadores externos que confiam no JWKS o aceitem como acesso.
+const (
+	tipoTokenAcesso  = "at+jwt"
+	tipoTokenDesafio = "2fa+jwt"
+	audienciaAcesso  = "empresa-app-api"
+	audienciaDesafio = "empresa-app-2fa"
 )
 
 type AuthService struct {
-	colaboradorRepo *repository.ColaboradorRepository
+	colaboradorRepo  *repository.ColaboradorRepository
+	refreshTokenRepo *repository.RefreshTokenRepository
+	revogacao        *RevogacaoService
+	sessoes          *SessaoService
+	doisFatores      *DoisFatoresService
+	tentativas       *TentativaLoginService
+	keys             *jwtkeys.KeySet
+	config           config.AuthConfig
 }
 
-func NewAuthService(colaboradorRepo *repository.ColaboradorRepository) *AuthService {
-	return &AuthService{colaboradorRepo: colaboradorRepo}
+func NewAuthService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	refreshTokenRepo *repository.RefreshTokenRepository,
+	revogacao *RevogacaoService,
+	sessoes *SessaoService,
+	doisFatores *DoisFatoresService,
+	tentativas *TentativaLoginService,
+	keys *jwtkeys.KeySet,
+	cfg config.AuthConfig,
+) *AuthService {
+	return &AuthService{
+		colaboradorRepo:  colaboradorRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revogacao:        revogacao,
+		sessoes:          sessoes,
+		doisFatores:      doisFatores,
+		tentativas:       tentativas,
+		keys:             keys,
+		config:           cfg,
+	}
 }
 
-func (s *AuthService) Login(email, senha string) (*model.LoginResponse, error) {
+func (s *AuthService) Login(email, senha, ip, userAgent string) (*model.LoginResponse, error) {
+	// Conta ou IP em espera após falhas anteriores
+	if err := s.tentativas.Verificar(email, ip); err != nil {
+		s.tentativas.RegistrarHistorico(nil, email, ip, userAgent, LoginBloqueado)
+		return nil, err
+	}
+
 	colaborador, err := s.colaboradorRepo.GetByEmail(email)
 	if err != nil {
-		return nil, errors.New("credenciais inválidas")
+		if err := s.registrarFalha(nil, email, ip, userAgent, LoginFalha); err != nil {
+			return nil, err
+		}
+		return nil, ErrCredenciaisInvalidas
 	}
 
 	// Verificar senha
 	if !s.colaboradorRepo.VerifyPassword(colaborador.Senha, senha) {
-		return nil, errors.New("credenciais inválidas")
+		if err := s.registrarFalha(&colaborador.ID, email, ip, userAgent, LoginFalha); err != nil {
+			return nil, err
+		}
+		return nil, ErrCredenciaisInvalidas
+	}
+
+	// Atualizar o hash para o algoritmo e os parâmetros atuais
+	if s.colaboradorRepo.NeedsRehash(colaborador.Senha) {
+		s.rehash(colaborador.ID, senha)
 	}
 
 	// Remover senha para não retornar no JSON
 	colaborador.Senha = ""
 
-	// Gerar token JWT
-	token, err := s.GenerateToken(colaborador.ID, colaborador.CargoID)
+	// Colaboradores desligados não podem entrar
+	if AcessoDoStatus(colaborador.Status) == AcessoNegado {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, email, ip, userAgent, LoginSemAcesso)
+		return nil, ErrColaboradorSemAcesso
+	}
+
+	if desafio, err := s.segundoFator(colaborador, ip, userAgent); err != nil || desafio != nil {
+		return desafio, err
+	}
+
+	return s.loginConcluido(colaborador, ip, userAgent)
+}
+
+// VerificarDoisFatores conclui o login validando o código TOTP ou de recuperação
+func (s *AuthService) VerificarDoisFatores(desafio, codigo, ip, userAgent string) (*model.LoginResponse, error) {
+	d, err := s.validarDesafio(desafio)
+	if err != nil {
+		return nil, err
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByID(d.colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Códigos de 2FA contam para o mesmo limite de tentativas da conta
+	if err := s.tentativas.Verificar(colaborador.Email, ip); err != nil {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginBloqueado)
+		return nil, err
+	}
+
+	if err := s.doisFatores.Verificar(d.colaboradorID, codigo); err != nil {
+		if registroErr := s.registrarFalha(&colaborador.ID, colaborador.Email, ip, userAgent, LoginFalha2FA); registroErr != nil {
+			return nil, registroErr
+		}
+		return nil, err
+	}
+
+	if err := s.consumirDesafio(d); err != nil {
+		return nil, err
+	}
+
+	return s.loginConcluido(colaborador, ip, userAgent)
+}
+
+// IniciarCadastroDoisFatores inicia o cadastro obrigatório do 2FA durante o login
+func (s *AuthService) IniciarCadastroDoisFatores(desafio string) (*model.DoisFatoresCadastroResponse, error) {
+	d, err := s.validarDesafio(desafio)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.doisFatores.IniciarCadastro(d.colaboradorID)
+}
+
+// AtivarDoisFatores conclui o cadastro obrigatório do 2FA e o login
+func (s *AuthService) AtivarDoisFatores(desafio, codigo, ip, userAgent string) (*model.DoisFatoresAtivacaoResponse, error) {
+	d, err := s.validarDesafio(desafio)
+	if err != nil {
+		return nil, err
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByID(d.colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Códigos de ativação contam para o mesmo limite de tentativas da conta
+	if err := s.tentativas.Verificar(colaborador.Email, ip); err != nil {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginBloqueado)
+		return nil, err
+	}
+
+	codigos, err := s.doisFatores.Ativar(d.colaboradorID, codigo)
+	if err != nil {
+		if errors.Is(err, ErrCodigo2FAInvalido) {
+			if registroErr := s.registrarFalha(&colaborador.ID, colaborador.Email, ip, userAgent, LoginFalha2FA); registroErr != nil {
+				return nil, registroErr
+			}
+		}
+		return nil, err
+	}
+
+	if err := s.consumirDesafio(d); err != nil {
+		return nil, err
+	}
+
+	login, err := s.loginConcluido(colaborador, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.DoisFatoresAtivacaoResponse{
+		CodigosRecuperacao: codigos,
+		Login:              login,
+	}, nil
+}
+
+// LoginExterno conclui o login de um colaborador já autenticado por um
+// provedor de identidade externo. O 2FA da aplicação é exigido como no login
+// com senha, independentemente do que o provedor tenha verificado.
+func (s *AuthService) LoginExterno(colaborador *model.Colaborador, ip, userAgent string) (*model.LoginResponse, error) {
+	colaborador.Senha = ""
+
+	if AcessoDoStatus(colaborador.Status) == AcessoNegado {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginSemAcesso)
+		return nil, ErrColaboradorSemAcesso
+	}
+
+	if desafio, err := s.segundoFator(colaborador, ip, userAgent); err != nil || desafio != nil {
+		return desafio, err
+	}
+
+	return s.loginConcluido(colaborador, ip, userAgent)
+}
+
+// segundoFator retorna o desafio de 2FA quando o colaborador já cadastrou o
+// segundo fator ou a política do cargo o exige, e nil quando o login pode ser
+// concluído sem ele
+func (s *AuthService) segundoFator(colaborador *model.Colaborador, ip, userAgent string) (*model.LoginResponse, error) {
+	ativo, err := s.doisFatores.Ativo(colaborador.ID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginDesafio2FA)
+		return s.desafio(colaborador.ID, false)
+	}
+
+	exigido, err := s.doisFatores.Exigido(colaborador.CargoID)
+	if err != nil {
+		return nil, err
+	}
+	if exigido {
+		s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginDesafio2FA)
+		return s.desafio(colaborador.ID, true)
+	}
+
+	return nil, nil
+}
+
+// rehash refaz o hash da senha após um login bem-sucedido. Falhas são apenas
+// registradas no log, pois o hash antigo continua válido.
+func (s *AuthService) rehash(colaboradorID int, senha string) {
+	hash, err := s.colaboradorRepo.HashPassword(senha)
+	if err == nil {
+		err = s.colaboradorRepo.UpdatePassword(colaboradorID, hash)
+	}
+	if err != nil {
+		log.Printf("Erro ao atualizar hash da senha do colaborador %d: %v", colaboradorID, err)
+	}
+}
+
+// registrarFalha registra a falha no histórico e nos contadores de tentativas
+func (s *AuthService) registrarFalha(colaboradorID *int, email, ip, userAgent, resultado string) error {
+	s.tentativas.RegistrarHistorico(colaboradorID, email, ip, userAgent, resultado)
+	return s.tentativas.RegistrarFalha(email, ip)
+}
+
+// loginConcluido zera o contador de falhas da conta e inicia a sessão
+func (s *AuthService) loginConcluido(colaborador *model.Colaborador, ip, userAgent string) (*model.LoginResponse, error) {
+	if err := s.tentativas.RegistrarSucesso(colaborador.Email, ip); err != nil {
+		return nil, err
+	}
+
+	s.tentativas.RegistrarHistorico(&colaborador.ID, colaborador.Email, ip, userAgent, LoginSucesso)
+
+	return s.iniciarSessao(colaborador, ip, userAgent)
+}
+
+// iniciarSessao emite os tokens de um login concluído; cada login inicia uma
+// nova sessão com sua própria família de refresh tokens
+func (s *AuthService) iniciarSessao(colaborador *model.Colaborador, ip, userAgent string) (*model.LoginResponse, error) {
+	// O status pode ter mudado durante o desafio de 2FA
+	if AcessoDoStatus(colaborador.Status) == AcessoNegado {
+		return nil, ErrColaboradorSemAcesso
+	}
+
+	familia := uuid.New().String()
+
+	sessao, err := s.sessoes.Registrar(colaborador.ID, familia, ip, userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	response, _, err := s.issueTokens(colaborador, familia, sessao.ID)
+	return response, err
+}
+
+// desafio2FA é um desafio de 2FA já validado
+type desafio2FA struct {
+	colaboradorID int
+	jti           string
+	expiraEm      time.Time
+}
+
+// desafio emite um token de curta duração e de uso único que só serve para
+// concluir o login com o segundo fator
+func (s *AuthService) desafio(colaboradorID int, cadastroPendente bool) (*model.LoginResponse, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":  s.config.JWTIssuer,
+		"aud":  audienciaDesafio,
+		"tipo": "2fa",
+		"jti":  uuid.New().String(),
+		"id":   colaboradorID,
+		"iat":  instanteJWT(now),
+		"exp":  now.Add(desafio2FAExpiration).Unix(),
+	}
+
+	token, err := s.keys.Sign(claims, tipoTokenDesafio)
 	if err != nil {
 		return nil, err
 	}
 
 	return &model.LoginResponse{
-		Token: token,
-		User:  colaborador,
+		Requer2FA:           true,
+		Cadastro2FAPendente: cadastroPendente,
+		Desafio:             token,
 	}, nil
 }
 
-func (s *AuthService) GenerateToken(userID, cargoID int) (string, error) {
-	// Obter chave secreta de JWT
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "seu_segredo_jwt_aqui" // Apenas para desenvolvimento
+// validarDesafio confere a assinatura e a validade do desafio e se ele ainda
+// não foi usado
+func (s *AuthService) validarDesafio(desafio string) (*desafio2FA, error) {
+	claims, err := s.parseToken(desafio, tipoTokenDesafio, audienciaDesafio)
+	if err != nil || claims["tipo"] != "2fa" {
+		return nil, ErrDesafioInvalido
+	}
+
+	colaboradorID, ok := claims["id"].(float64)
+	if !ok {
+		return nil, ErrDesafioInvalido
+	}
+
+	jti, _ := claims["jti"].(string)
+	issuedAt, ok := emitidoEm(claims)
+	if jti == "" || !ok {
+		return nil, ErrDesafioInvalido
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, ErrDesafioInvalido
+	}
+
+	// Desafio já usado ou emitido antes de o colaborador ter as sessões revogadas
+	if s.revogacao.IsRevogado(jti, int(colaboradorID), 0, issuedAt) {
+		return nil, ErrDesafioInvalido
+	}
+
+	return &desafio2FA{
+		colaboradorID: int(colaboradorID),
+		jti:           jti,
+		expiraEm:      expiresAt.Time,
+	}, nil
+}
+
+// consumirDesafio impede que o desafio seja usado de novo
+func (s *AuthService) consumirDesafio(d *desafio2FA) error {
+	consumido, err := s.revogacao.ConsumirToken(d.jti, d.colaboradorID, d.expiraEm)
+	if err != nil {
+		return err
+	}
+	if !consumido {
+		return ErrDesafioInvalido
+	}
+	return nil
+}
+
+// Refresh troca um refresh token válido por um novo token de acesso e um novo
+// refresh token da mesma família. O token apresentado é consumido; se ele já
+// tiver sido usado, toda a família é revogada e a sessão encerrada.
+func (s *AuthService) Refresh(refreshToken, ip, userAgent string) (*model.LoginResponse, error) {
+	stored, err := s.refreshTokenRepo.GetByHash(s.hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	// Token já consumido ou revogado: possível roubo, revogar a família inteira
+	if stored.UsadoEm != nil || stored.RevogadoEm != nil {
+		if err := s.sessoes.EncerrarFamilia(stored.Familia); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReusado
+	}
+
+	if time.Now().After(stored.ExpiraEm) {
+		return nil, ErrRefreshTokenExpirado
+	}
+
+	// Consumir de forma atômica para evitar duas renovações concorrentes
+	consumed, err := s.refreshTokenRepo.MarkAsUsed(stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		if err := s.sessoes.EncerrarFamilia(stored.Familia); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReusado
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByID(stored.ColaboradorID)
+	if err != nil {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	if AcessoDoStatus(colaborador.Status) == AcessoNegado {
+		if err := s.sessoes.EncerrarFamilia(stored.Familia); err != nil {
+			return nil, err
+		}
+		return nil, ErrColaboradorSemAcesso
+	}
+
+	// Renovar a sessão; sessões encerradas não podem ser renovadas
+	sessao, err := s.sessoes.Registrar(colaborador.ID, stored.Familia, ip, userAgent)
+	if err != nil {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	response, newTokenID, err := s.issueTokens(colaborador, stored.Familia, sessao.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.SetReplacedBy(stored.ID, newTokenID); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Logout revoga o token de acesso atual e encerra a sessão. Com todos=true,
+// encerra todas as sessões do colaborador.
+func (s *AuthService) Logout(colaboradorID, sessaoID int, jti string, expiraEm time.Time, req model.LogoutRequest) error {
+	if req.Todos {
+		return s.RevogarTodos(colaboradorID)
 	}
 
+	if err := s.revogacao.RevogarToken(jti, colaboradorID, expiraEm); err != nil {
+		return err
+	}
+
+	if sessaoID != 0 {
+		if err := s.sessoes.Encerrar(colaboradorID, sessaoID); err != nil && !errors.Is(err, ErrSessaoNaoEncontrada) {
+			return err
+		}
+		return nil
+	}
+
+	// Token emitido sem sessão: encerrar pela família do refresh token
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.refreshTokenRepo.GetByHash(s.hashRefreshToken(req.RefreshToken))
+	if err != nil || stored.ColaboradorID != colaboradorID {
+		// Token desconhecido ou de outro colaborador: nada a revogar
+		return nil
+	}
+
+	return s.sessoes.EncerrarFamilia(stored.Familia)
+}
+
+// RevogarTodos encerra todas as sessões do colaborador, invalidando seus
+// tokens de acesso e refresh tokens
+func (s *AuthService) RevogarTodos(colaboradorID int) error {
+	return s.sessoes.EncerrarTodas(colaboradorID)
+}
+
+func (s *AuthService) tokenTTL() time.Duration {
+	return time.Duration(s.config.TokenExpiration) * time.Minute
+}
+
+// issueTokens gera o token de acesso e um novo refresh token na família
+// informada, retornando também o ID do refresh token persistido
+func (s *AuthService) issueTokens(colaborador *model.Colaborador, familia string, sessaoID int) (*model.LoginResponse, int, error) {
+	// Gerar token JWT
+	token, err := s.GenerateToken(colaborador.ID, colaborador.CargoID, sessaoID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	refreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stored := &model.RefreshToken{
+		ColaboradorID: colaborador.ID,
+		TokenHash:     s.hashRefreshToken(refreshToken),
+		Familia:       familia,
+		ExpiraEm:      time.Now().Add(time.Duration(s.config.RefreshExpiration) * time.Hour),
+	}
+	if err := s.refreshTokenRepo.Create(stored); err != nil {
+		return nil, 0, err
+	}
+
+	return &model.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.config.TokenExpiration * 60,
+		User:         colaborador,
+	}, stored.ID, nil
+}
+
+func (s *AuthService) GenerateToken(userID, cargoID, sessaoID int) (string, error) {
 	// Definir tempo de expiração do token
-	expirationTime := time.Now().Add(24 * time.Hour)
+	now := time.Now()
+	expirationTime := now.Add(s.tokenTTL())
 
 	// Criar claims
 	claims := jwt.MapClaims{
+		"iss":      s.config.JWTIssuer,
+		"aud":      audienciaAcesso,
+		"jti":      uuid.New().String(),
 		"id":       userID,
 		"cargo_id": cargoID,
+		"sid":      sessaoID,
+		"iat":      instanteJWT(now),
 		"exp":      expirationTime.Unix(),
 	}
 
-	// Criar token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Assinar token
-	tokenString, err := token.SignedString([]byte(jwtSecret))
+	// Criar e assinar token com a chave ativa
+	tokenString, err := s.keys.Sign(claims, tipoTokenAcesso)
 	if err != nil {
 		return "", err
 	}
@@ -74,30 +539,117 @@ func (s *AuthService) GenerateToken(userID, cargoID int) (string, error) {
 	return tokenString, nil
 }
 
+// GenerateImpersonationToken emite um token de acesso do colaborador alvo para
+// uma personificação. A claim "act" identifica o administrador que age em nome
+// do alvo (RFC 8693), inclusive para verificadores externos; o token não tem
+// sessão nem refresh token.
+func (s *AuthService) GenerateImpersonationToken(personificacao *model.Personificacao, cargoID int) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":            s.config.JWTIssuer,
+		"aud":            audienciaAcesso,
+		"jti":            personificacao.JTI,
+		"id":             personificacao.AlvoID,
+		"cargo_id":       cargoID,
+		"act":            map[string]interface{}{"id": personificacao.AtorID},
+		"personificacao": personificacao.ID,
+		"iat":            instanteJWT(time.Now()),
+		"exp":            personificacao.ExpiraEm.Unix(),
+	}
+
+	return s.keys.Sign(claims, tipoTokenAcesso)
+}
+
 func (s *AuthService) ValidateToken(tokenString string) (map[string]interface{}, error) {
-	// Obter chave secreta de JWT
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "seu_segredo_jwt_aqui" // Apenas para desenvolvimento
+	// Analisar token (a chave é escolhida pelo kid e o algoritmo validado)
+	claims, err := s.parseToken(tokenString, tipoTokenAcesso, audienciaAcesso)
+	if err != nil {
+		return nil, err
 	}
 
-	// Analisar token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Validar algoritmo de assinatura
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("método de assinatura inválido")
-		}
-		return []byte(jwtSecret), nil
-	})
+	// Desafios de 2FA não são tokens de acesso
+	if _, ok := claims["tipo"]; ok {
+		return nil, errors.New("token inválido")
+	}
+
+	// Verificar lista de revogação
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil, errors.New("token inválido: jti não encontrado")
+	}
+
+	userID, _ := claims["id"].(float64)
+	sessaoID, _ := claims["sid"].(float64)
+	issuedAt, ok := emitidoEm(claims)
+	if !ok {
+		return nil, errors.New("token inválido: iat não encontrado")
+	}
+
+	if s.revogacao.IsRevogado(jti, int(userID), int(sessaoID), issuedAt) {
+		return nil, ErrTokenRevogado
+	}
+
+	return claims, nil
+}
+
+// instanteJWT converte t para o formato NumericDate com precisão de
+// microssegundos, a mesma do banco. O iat precisa de fração de segundo para
+// que uma revogação de todos os tokens do colaborador não poupe os emitidos no
+// mesmo segundo nem recuse os emitidos logo depois.
+func instanteJWT(t time.Time) float64 {
+	return float64(t.UnixMicro()) / 1e6
+}
+
+// emitidoEm lê o iat com a precisão usada por instanteJWT
+func emitidoEm(claims jwt.MapClaims) (time.Time, bool) {
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(int64(math.Round(iat * 1e6))), true
+}
 
+// parseToken valida a assinatura, o tipo e as claims padrão do token, exigindo
+// o emissor desta API e exatamente a audiência informada
+func (s *AuthService) parseToken(tokenString, tipo, audiencia string) (jwt.MapClaims, error) {
+	token, err := s.keys.Parse(tokenString, tipo,
+		jwt.WithIssuer(s.config.JWTIssuer),
+		jwt.WithAudience(audiencia),
+		jwt.WithIssuedAt(),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verificar se token é válido
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("token inválido")
 	}
 
-	return nil, errors.New("token inválido")
+	aud, err := claims.GetAudience()
+	if err != nil || len(aud) != 1 {
+		return nil, errors.New("token inválido: audiência inesperada")
+	}
+
+	return claims, nil
+}
+
+// JWKS retorna as chaves públicas usadas para verificar os tokens emitidos
+func (s *AuthService) JWKS() jwtkeys.JWKS {
+	return s.keys.JWKS()
+}
+
+// hashRefreshToken calcula o HMAC do refresh token; apenas o hash é persistido
+func (s *AuthService) hashRefreshToken(token string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.RefreshSecret))
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateOpaqueToken gera um token aleatório de 256 bits codificado em base64 URL-safe
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+var (
+	ErrCargoNaoEncontrado    = errors.New("cargo não encontrado")
+	ErrCargoNomeEmUso        = errors.New("já existe um cargo com este nome")
+	ErrCargoComColaboradores = errors.New("cargo possui colaboradores; transfira-os para outro cargo antes de excluir")
+)
+
+// CargoService mantém o cadastro de cargos. Toda alteração é registrada no
+// histórico com o autor.
+type CargoService struct {
+	cargoRepo *repository.CargoRepository
+	permissao *PermissaoService
+}
+
+func NewCargoService(cargoRepo *repository.CargoRepository, permissao *PermissaoService) *CargoService {
+	return &CargoService{
+		cargoRepo: cargoRepo,
+		permissao: permissao,
+	}
+}
+
+func (s *CargoService) List() ([]*model.Cargo, error) {
+	return s.cargoRepo.List()
+}
+
+// GetByID retorna o cargo com a quantidade de colaboradores. As permissões
+// ficam restritas à gestão de permissões (PermissaoService.GetCargo).
+func (s *CargoService) GetByID(id int) (*model.Cargo, error) {
+	cargo, err := s.cargoRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCargoNaoEncontrado
+	}
+
+	cargo.TotalColaboradores, err = s.cargoRepo.CountColaboradores(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return cargo, nil
+}
+
+func (s *CargoService) Create(req model.CargoRequest, alteradoPor int) (*model.Cargo, error) {
+	cargo := &model.Cargo{}
+	if err := s.aplicar(cargo, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.cargoRepo.Create(cargo, alteradoPor); err != nil {
+		return nil, err
+	}
+
+	return cargo, nil
+}
+
+func (s *CargoService) Update(id int, req model.CargoRequest, alteradoPor int) (*model.Cargo, error) {
+	anterior, err := s.cargoRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCargoNaoEncontrado
+	}
+
+	cargo := *anterior
+	if err := s.aplicar(&cargo, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.cargoRepo.Update(&cargo, anterior, alteradoPor); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+// Delete exclui o cargo, desde que nenhum colaborador esteja nele
+func (s *CargoService) Delete(id, alteradoPor int) error {
+	cargo, err := s.cargoRepo.GetByID(id)
+	if err != nil {
+		return ErrCargoNaoEncontrado
+	}
+
+	excluido, err := s.cargoRepo.Delete(cargo, alteradoPor)
+	if err != nil {
+		return err
+	}
+	if !excluido {
+		return ErrCargoComColaboradores
+	}
+
+	// Remover do cache as permissões do cargo excluído
+	return s.permissao.Load()
+}
+
+// Historico retorna as alterações do cargo, incluindo as de cargos já excluídos
+func (s *CargoService) Historico(id int) ([]*model.HistoricoCargo, error) {
+	return s.cargoRepo.ListHistorico(id)
+}
+
+func (s *CargoService) aplicar(cargo *model.Cargo, req model.CargoRequest) error {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+
+	existe, err := s.cargoRepo.ExistsNome(nome, cargo.ID)
+	if err != nil {
+		return err
+	}
+	if existe {
+		return ErrCargoNomeEmUso
+	}
+
+	cargo.Nome = nome
+	cargo.Descricao = strings.TrimSpace(req.Descricao)
+	cargo.Exige2FA = req.Exige2FA
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+var (
+	ErrCentroCustoNaoEncontrado = errors.New("centro de custo não encontrado")
+	ErrCentroCustoCodigoEmUso   = errors.New("já existe um centro de custo com este código")
+	ErrCentroCustoEmUso         = errors.New("centro de custo já teve colaboradores alocados; desative-o em vez de excluir")
+	ErrCodigoObrigatorio        = errors.New("código é obrigatório")
+)
+
+// CentroCustoService mantém o cadastro de centros de custo. Centros de custo
+// inativos continuam no histórico de alocações, mas não recebem novas.
+type CentroCustoService struct {
+	centroCustoRepo *repository.CentroCustoRepository
+}
+
+func NewCentroCustoService(centroCustoRepo *repository.CentroCustoRepository) *CentroCustoService {
+	return &CentroCustoService{centroCustoRepo: centroCustoRepo}
+}
+
+func (s *CentroCustoService) List(incluirInativos bool) ([]*model.CentroCusto, error) {
+	return s.centroCustoRepo.List(incluirInativos)
+}
+
+func (s *CentroCustoService) GetByID(id int) (*model.CentroCusto, error) {
+	centro, err := s.centroCustoRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrCentroCustoNaoEncontrado
+	}
+	return centro, nil
+}
+
+func (s *CentroCustoService) Create(req model.CentroCustoRequest) (*model.CentroCusto, error) {
+	centro := &model.CentroCusto{Ativo: true}
+	if err := s.aplicar(centro, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.centroCustoRepo.Create(centro); err != nil {
+		return nil, err
+	}
+
+	return centro, nil
+}
+
+func (s *CentroCustoService) Update(id int, req model.CentroCustoRequest) (*model.CentroCusto, error) {
+	centro, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.aplicar(centro, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.centroCustoRepo.Update(centro); err != nil {
+		return nil, err
+	}
+
+	return centro, nil
+}
+
+// Delete exclui o centro de custo, desde que nunca tenha tido colaboradores
+func (s *CentroCustoService) Delete(id int) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+
+	excluido, err := s.centroCustoRepo.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !excluido {
+		return ErrCentroCustoEmUso
+	}
+
+	return nil
+}
+
+func (s *CentroCustoService) aplicar(centro *model.CentroCusto, req model.CentroCustoRequest) error {
+	codigo := strings.ToUpper(strings.TrimSpace(req.Codigo))
+	if codigo == "" {
+		return ErrCodigoObrigatorio
+	}
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+
+	existe, err := s.centroCustoRepo.ExistsCodigo(codigo, centro.ID)
+	if err != nil {
+		return err
+	}
+	if existe {
+		return ErrCentroCustoCodigoEmUso
+	}
+
+	centro.Codigo = codigo
+	centro.Nome = nome
+	if req.Ativo != nil {
+		centro.Ativo = *req.Ativo
+	}
+
+	return nil
+}
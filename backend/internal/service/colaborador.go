@@ -1,35 +1,84 @@
 package service
 
 import (
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
 	"empresa-app/backend/internal/model"
 	"empresa-app/backend/internal/repository"
-	"errors"
+)
 
-	"golang.org/x/crypto/bcrypt"
+var (
+	ErrSenhaAtualIncorreta      = errors.New("senha atual incorreta")
+	ErrColaboradorNaoEncontrado = errors.New("colaborador não encontrado")
+	ErrNomeObrigatorio          = errors.New("nome é obrigatório")
+	ErrEmailEmUso               = errors.New("email já cadastrado para outro colaborador")
+	ErrCargoInvalido            = errors.New("cargo não encontrado")
+	ErrDataAdmissao             = errors.New("data de admissão inválida: use o formato AAAA-MM-DD")
+	ErrGestorNaoEncontrado      = errors.New("gestor não encontrado")
+	ErrGestorDesligado          = errors.New("gestor desligado não pode receber subordinados")
+	ErrGestorProprio            = errors.New("colaborador não pode ser gestor de si mesmo")
+	ErrGestorCiclo              = errors.New("o colaborador está acima do gestor na hierarquia; a alteração criaria um ciclo")
+	ErrCampoHistoricoInvalido   = errors.New("campo inválido: use " + strings.Join(repository.CamposHistoricoColaborador, ", "))
 )
 
+// Tamanho máximo de uma página da listagem de colaboradores
+const maxColaboradoresPorPagina = 100
+
+// Tamanho máximo de uma página do histórico de alterações
+const maxHistoricoPorPagina = 100
+
 type ColaboradorService struct {
 	colaboradorRepo *repository.ColaboradorRepository
+	cargoRepo       *repository.CargoRepository
+	politica        *PoliticaSenhaService
+	sessoes         *SessaoService
+	convites        *RedefinicaoSenhaService
+	dadosBancarios  *DadosBancariosService
 }
 
-func NewColaboradorService(colaboradorRepo *repository.ColaboradorRepository) *ColaboradorService {
-	return &ColaboradorService{colaboradorRepo: colaboradorRepo}
+func NewColaboradorService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	cargoRepo *repository.CargoRepository,
+	politica *PoliticaSenhaService,
+	sessoes *SessaoService,
+	convites *RedefinicaoSenhaService,
+	dadosBancarios *DadosBancariosService,
+) *ColaboradorService {
+	return &ColaboradorService{
+		colaboradorRepo: colaboradorRepo,
+		cargoRepo:       cargoRepo,
+		politica:        politica,
+		sessoes:         sessoes,
+		convites:        convites,
+		dadosBancarios:  dadosBancarios,
+	}
 }
 
+// GetByID retorna o colaborador com os dados bancários mascarados
 func (s *ColaboradorService) GetByID(id int) (*model.Colaborador, error) {
-	return s.colaboradorRepo.GetByID(id)
+	colaborador, err := s.colaboradorRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dadosBancarios.Mascarar(colaborador)
+	return colaborador, nil
 }
 
-func (s *ColaboradorService) Update(colaborador *model.Colaborador) error {
+func (s *ColaboradorService) Update(colaborador *model.Colaborador, alteradoPor int) error {
 	// Validar dados
 	if colaborador.Nome == "" {
 		return errors.New("nome é obrigatório")
 	}
 
-	return s.colaboradorRepo.Update(colaborador)
+	return s.colaboradorRepo.Update(colaborador, alteradoPor)
 }
 
-func (s *ColaboradorService) Create(colaborador *model.Colaborador) error {
+func (s *ColaboradorService) Create(colaborador *model.Colaborador, alteradoPor int) error {
 	// Validar dados
 	if colaborador.Nome == "" {
 		return errors.New("nome é obrigatório")
@@ -43,42 +92,234 @@ func (s *ColaboradorService) Create(colaborador *model.Colaborador) error {
 		return errors.New("senha é obrigatória")
 	}
 
-	return s.colaboradorRepo.Create(colaborador)
+	return s.colaboradorRepo.Create(colaborador, alteradoPor)
 }
 
-// AlterarSenha altera a senha do colaborador
-func (s *ColaboradorService) AlterarSenha(id int, senhaAtual, novaSenha string) error {
-	// Validar tamanho da senha
-	if len(novaSenha) < 6 {
-		return errors.New("nova senha deve ter pelo menos 6 caracteres")
+// AlterarSenha altera a senha do colaborador e encerra as demais sessões
+func (s *ColaboradorService) AlterarSenha(id, sessaoAtual int, senhaAtual, novaSenha string) error {
+	colaborador, err := s.colaboradorRepo.GetByIDWithPassword(id)
+	if err != nil {
+		return err
+	}
+
+	// Verificar senha atual
+	if !s.colaboradorRepo.VerifyPassword(colaborador.Senha, senhaAtual) {
+		return ErrSenhaAtualIncorreta
 	}
 
-	// Obter colaborador com senha
-	// Como não existe GetByIDWithPassword, vamos usar GetByID e depois buscar a senha
-	colaborador, err := s.colaboradorRepo.GetByID(id)
-	if err != nil {
+	if err := s.politica.Validar(id, colaborador.Senha, novaSenha); err != nil {
 		return err
 	}
 
-	// Como o repositório não tem o método para verificar senha diretamente,
-	// vamos buscar novamente o colaborador pelo email para obter a senha
-	colaboradorComSenha, err := s.colaboradorRepo.GetByEmail(colaborador.Email)
+	// Hash da nova senha
+	hashedSenha, err := s.colaboradorRepo.HashPassword(novaSenha)
 	if err != nil {
 		return err
 	}
 
-	// Verificar senha atual
-	if !s.colaboradorRepo.VerifyPassword(colaboradorComSenha.Senha, senhaAtual) {
-		return errors.New("senha atual incorreta")
+	if err := s.colaboradorRepo.UpdatePassword(id, hashedSenha); err != nil {
+		return err
 	}
 
-	// Hash da nova senha
-	hashedSenha, err := bcrypt.GenerateFromPassword([]byte(novaSenha), bcrypt.DefaultCost)
-	if err != nil {
+	if err := s.politica.Registrar(id, colaborador.Senha); err != nil {
 		return err
 	}
 
-	// Como não existe UpdatePassword, vamos usar Update para atualizar o colaborador completo
-	colaborador.Senha = string(hashedSenha)
-	return s.colaboradorRepo.Update(colaborador)
+	return s.sessoes.EncerrarOutras(id, sessaoAtual)
+}
+
+// List busca colaboradores com filtros e paginação
+func (s *ColaboradorService) List(filtro model.ColaboradorFiltro) (*model.ColaboradorLista, error) {
+	filtro.Busca = strings.TrimSpace(filtro.Busca)
+	filtro.Status = strings.ToLower(strings.TrimSpace(filtro.Status))
+	if filtro.Status != "" {
+		if _, ok := regrasStatus[filtro.Status]; !ok {
+			return nil, ErrStatusInvalido
+		}
+	}
+
+	if filtro.Limit <= 0 || filtro.Limit > maxColaboradoresPorPagina {
+		filtro.Limit = maxColaboradoresPorPagina
+	}
+	if filtro.Offset < 0 {
+		filtro.Offset = 0
+	}
+
+	colaboradores, total, err := s.colaboradorRepo.List(filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ColaboradorLista{
+		Colaboradores: colaboradores,
+		Total:         total,
+		Limit:         filtro.Limit,
+		Offset:        filtro.Offset,
+	}, nil
+}
+
+// Equipe lista os subordinados diretos do gestor
+func (s *ColaboradorService) Equipe(gestorID, limit, offset int) (*model.ColaboradorLista, error) {
+	return s.List(model.ColaboradorFiltro{GestorID: gestorID, Limit: limit, Offset: offset})
+}
+
+// DefinirGestor altera o gestor direto do colaborador (nil remove). Os
+// documentos pendentes do colaborador passam para o novo gestor.
+func (s *ColaboradorService) DefinirGestor(id int, gestorID *int, alteradoPor int) (*model.Colaborador, error) {
+	if _, err := s.colaboradorRepo.GetByID(id); err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+
+	if gestorID != nil {
+		if *gestorID == id {
+			return nil, ErrGestorProprio
+		}
+
+		gestor, err := s.colaboradorRepo.GetByID(*gestorID)
+		if err != nil {
+			return nil, ErrGestorNaoEncontrado
+		}
+		if gestor.Status == StatusDesligado {
+			return nil, ErrGestorDesligado
+		}
+	}
+
+	alterado, err := s.colaboradorRepo.UpdateGestor(id, gestorID, alteradoPor)
+	if err != nil {
+		return nil, err
+	}
+	if !alterado {
+		return nil, ErrGestorCiclo
+	}
+
+	return s.GetByID(id)
+}
+
+// Historico retorna as alterações do cadastro do colaborador, da mais recente
+// para a mais antiga. Com campo (ex.: cargo), apenas as que o alteraram.
+func (s *ColaboradorService) Historico(id int, campo string, limit, offset int) ([]*model.HistoricoColaborador, error) {
+	campo = strings.ToLower(strings.TrimSpace(campo))
+	if campo != "" && !slices.Contains(repository.CamposHistoricoColaborador, campo) {
+		return nil, ErrCampoHistoricoInvalido
+	}
+
+	if _, err := s.colaboradorRepo.GetByID(id); err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+
+	if limit <= 0 || limit > maxHistoricoPorPagina {
+		limit = maxHistoricoPorPagina
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.colaboradorRepo.ListHistorico(id, campo, limit, offset)
+}
+
+// Cadastrar cria o colaborador com uma senha aleatória e envia o convite para
+// que ele defina a própria senha
+func (s *ColaboradorService) Cadastrar(req model.ColaboradorAdminRequest, alteradoPor int) (*model.Colaborador, error) {
+	colaborador := &model.Colaborador{Status: StatusAtivo, DataAdmissao: time.Now()}
+	if err := s.aplicarCadastro(colaborador, req); err != nil {
+		return nil, err
+	}
+
+	// Senha aleatória que ninguém conhece até o colaborador definir a própria
+	senha, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	colaborador.Senha = senha
+
+	if err := s.colaboradorRepo.Create(colaborador, alteradoPor); err != nil {
+		if errors.Is(err, repository.ErrEmailDuplicado) {
+			return nil, ErrEmailEmUso
+		}
+		return nil, err
+	}
+	colaborador.Senha = ""
+
+	// O cadastro já existe; o convite pode ser reenviado se o envio falhar
+	if err := s.convites.EnviarConvite(colaborador); err != nil {
+		log.Printf("Erro ao enviar convite ao colaborador %d: %v", colaborador.ID, err)
+	}
+
+	return colaborador, nil
+}
+
+// ReenviarConvite envia um novo link para o colaborador definir a senha
+func (s *ColaboradorService) ReenviarConvite(id int) error {
+	colaborador, err := s.colaboradorRepo.GetByID(id)
+	if err != nil {
+		return ErrColaboradorNaoEncontrado
+	}
+
+	return s.convites.EnviarConvite(colaborador)
+}
+
+// AtualizarCadastro altera nome, email, cargo, data de admissão e telefone.
+// A troca de cargo encerra as sessões do colaborador.
+func (s *ColaboradorService) AtualizarCadastro(id int, req model.ColaboradorAdminRequest, alteradoPor int) (*model.Colaborador, error) {
+	colaborador, err := s.colaboradorRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	cargoAnterior := colaborador.CargoID
+
+	if err := s.aplicarCadastro(colaborador, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.colaboradorRepo.UpdateCadastro(colaborador, alteradoPor); err != nil {
+		if errors.Is(err, repository.ErrEmailDuplicado) {
+			return nil, ErrEmailEmUso
+		}
+		return nil, err
+	}
+
+	// O cargo vai no token de acesso e define as permissões: os tokens
+	// emitidos com o cargo anterior deixam de valer
+	if colaborador.CargoID != cargoAnterior {
+		if err := s.sessoes.EncerrarTodas(id); err != nil {
+			return nil, err
+		}
+	}
+
+	s.dadosBancarios.Mascarar(colaborador)
+	return colaborador, nil
+}
+
+// aplicarCadastro valida a requisição e copia os dados para o colaborador
+func (s *ColaboradorService) aplicarCadastro(colaborador *model.Colaborador, req model.ColaboradorAdminRequest) error {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if existente, err := s.colaboradorRepo.GetByEmail(email); err == nil && existente.ID != colaborador.ID {
+		return ErrEmailEmUso
+	}
+
+	cargo, err := s.cargoRepo.GetByID(req.CargoID)
+	if err != nil {
+		return ErrCargoInvalido
+	}
+
+	if req.DataAdmissao != "" {
+		data, err := time.Parse("2006-01-02", req.DataAdmissao)
+		if err != nil {
+			return ErrDataAdmissao
+		}
+		colaborador.DataAdmissao = data
+	}
+
+	colaborador.Nome = nome
+	colaborador.Email = email
+	colaborador.CargoID = cargo.ID
+	colaborador.CargoNome = cargo.Nome
+	colaborador.Telefone = strings.TrimSpace(req.Telefone)
+
+	return nil
 }
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// Escopos que podem ser concedidos a chaves de API
+const (
+	EscopoDocumentosRead = "documentos:read"
+)
+
+var escoposValidos = map[string]bool{
+	EscopoDocumentosRead: true,
+}
+
+// PrefixoChaveAPI identifica chaves de API no cabeçalho Authorization
+const PrefixoChaveAPI = "rls_"
+
+var ErrChaveAPIInvalida = errors.New("chave de API inválida")
+
+type ContaServicoService struct {
+	contaServicoRepo *repository.ContaServicoRepository
+}
+
+func NewContaServicoService(contaServicoRepo *repository.ContaServicoRepository) *ContaServicoService {
+	return &ContaServicoService{contaServicoRepo: contaServicoRepo}
+}
+
+func (s *ContaServicoService) Create(conta *model.ContaServico) error {
+	if conta.Nome == "" {
+		return errors.New("nome é obrigatório")
+	}
+
+	return s.contaServicoRepo.Create(conta)
+}
+
+func (s *ContaServicoService) List() ([]*model.ContaServico, error) {
+	return s.contaServicoRepo.List()
+}
+
+// Desativar desativa a conta de serviço e revoga todas as suas chaves
+func (s *ContaServicoService) Desativar(id int) error {
+	return s.contaServicoRepo.Deactivate(id)
+}
+
+// CriarChave gera uma nova chave de API para a conta. A chave completa só é
+// retornada neste momento.
+func (s *ContaServicoService) CriarChave(contaServicoID int, escopos []string, expiraEm *time.Time, criadoPor int) (*model.ChaveAPICreateResponse, error) {
+	conta, err := s.contaServicoRepo.GetByID(contaServicoID)
+	if err != nil {
+		return nil, err
+	}
+	if !conta.Ativo {
+		return nil, errors.New("conta de serviço inativa")
+	}
+
+	if len(escopos) == 0 {
+		return nil, errors.New("ao menos um escopo é obrigatório")
+	}
+	for _, escopo := range escopos {
+		if !escoposValidos[escopo] {
+			return nil, errors.New("escopo inválido: " + escopo)
+		}
+	}
+
+	if expiraEm != nil && expiraEm.Before(time.Now()) {
+		return nil, errors.New("data de expiração deve ser futura")
+	}
+
+	prefixo, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+
+	segredo, err := generateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	chave := &model.ChaveAPI{
+		ContaServicoID: contaServicoID,
+		Prefixo:        prefixo,
+		SegredoHash:    hashToken(segredo),
+		Escopos:        escopos,
+		ExpiraEm:       expiraEm,
+		CriadoPor:      &criadoPor,
+	}
+	if err := s.contaServicoRepo.CreateChave(chave); err != nil {
+		return nil, err
+	}
+
+	return &model.ChaveAPICreateResponse{
+		Chave:    PrefixoChaveAPI + prefixo + "_" + segredo,
+		ChaveAPI: chave,
+	}, nil
+}
+
+func (s *ContaServicoService) ListChaves(contaServicoID int) ([]*model.ChaveAPI, error) {
+	return s.contaServicoRepo.ListChaves(contaServicoID)
+}
+
+func (s *ContaServicoService) RevogarChave(id int) error {
+	return s.contaServicoRepo.RevokeChave(id)
+}
+
+// Autenticar valida uma chave de API no formato rls_<prefixo>_<segredo>
+func (s *ContaServicoService) Autenticar(chaveCompleta string) (*model.ChaveAPI, error) {
+	if !strings.HasPrefix(chaveCompleta, PrefixoChaveAPI) {
+		return nil, ErrChaveAPIInvalida
+	}
+
+	prefixo, segredo, ok := strings.Cut(strings.TrimPrefix(chaveCompleta, PrefixoChaveAPI), "_")
+	if !ok || prefixo == "" || segredo == "" {
+		return nil, ErrChaveAPIInvalida
+	}
+
+	chave, err := s.contaServicoRepo.GetChaveAtivaByPrefixo(prefixo)
+	if err != nil {
+		return nil, ErrChaveAPIInvalida
+	}
+
+	if subtle.ConstantTimeCompare([]byte(chave.SegredoHash), []byte(hashToken(segredo))) != 1 {
+		return nil, ErrChaveAPIInvalida
+	}
+
+	if chave.RevogadoEm != nil {
+		return nil, errors.New("chave de API revogada")
+	}
+
+	if chave.ExpiraEm != nil && time.Now().After(*chave.ExpiraEm) {
+		return nil, errors.New("chave de API expirada")
+	}
+
+	if err := s.contaServicoRepo.TouchChave(chave.ID); err != nil {
+		log.Printf("Erro ao registrar uso da chave de API %s: %v", chave.Prefixo, err)
+	}
+
+	return chave, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
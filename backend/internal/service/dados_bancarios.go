@@ -0,0 +1,334 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/envelope"
+)
+
+var (
+	ErrBancoInvalido                = errors.New("banco inválido: informe o código de 3 dígitos")
+	ErrAgenciaInvalida              = errors.New("agência inválida")
+	ErrContaInvalida                = errors.New("conta inválida")
+	ErrChavePixInvalida             = errors.New("chave PIX inválida: use CPF, CNPJ, email, telefone com +55 ou chave aleatória")
+	ErrDadosBancariosIncompletos    = errors.New("informe banco, agência e conta juntos, ou apenas a chave PIX")
+	ErrDadosBancariosNaoCadastrados = errors.New("colaborador não possui dados bancários cadastrados")
+	ErrDadosBancariosIndisponiveis  = errors.New("não foi possível ler os dados bancários")
+	ErrMotivoObrigatorio            = errors.New("motivo é obrigatório")
+)
+
+// Motivo registrado quando o próprio colaborador consulta seus dados
+const motivoConsultaPropria = "consulta pelo próprio colaborador"
+
+// Tamanho máximo de uma página do registro de consultas
+const maxRevelacoesPorPagina = 100
+
+var (
+	regexBanco   = regexp.MustCompile(`^\d{3}$`)
+	regexAgencia = regexp.MustCompile(`^\d{1,5}(-[\dX])?$`)
+	regexConta   = regexp.MustCompile(`^\d{1,20}(-[\dX])?$`)
+
+	regexPixDocumento = regexp.MustCompile(`^(\d{11}|\d{14})$`) // CPF ou CNPJ
+	regexPixTelefone  = regexp.MustCompile(`^\+55\d{10,11}$`)
+	regexPixEmail     = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	regexPixAleatoria = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// DadosBancariosService guarda os dados bancários cifrados em envelope e
+// controla a revelação dos dados completos, que é sempre registrada
+type DadosBancariosService struct {
+	colaboradorRepo *repository.ColaboradorRepository
+	revelacaoRepo   *repository.RevelacaoDadosBancariosRepository
+	chaveiro        *envelope.Chaveiro
+}
+
+func NewDadosBancariosService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	revelacaoRepo *repository.RevelacaoDadosBancariosRepository,
+	chaveiro *envelope.Chaveiro,
+) *DadosBancariosService {
+	return &DadosBancariosService{
+		colaboradorRepo: colaboradorRepo,
+		revelacaoRepo:   revelacaoRepo,
+		chaveiro:        chaveiro,
+	}
+}
+
+// Mascarar preenche os dados bancários mascarados do colaborador. Falhas ao
+// decifrar são registradas no log e os dados ficam ausentes na resposta.
+func (s *DadosBancariosService) Mascarar(colaborador *model.Colaborador) {
+	dados, err := s.abrir(colaborador.ID, colaborador.DadosBancariosCifrados)
+	if err != nil {
+		log.Printf("Erro ao decifrar dados bancários do colaborador %d: %v", colaborador.ID, err)
+		colaborador.DadosBancarios = nil
+		return
+	}
+
+	colaborador.DadosBancarios = mascararDadosBancarios(dados)
+}
+
+// Atualizar valida e grava os dados bancários do colaborador, que só podem
+// ser alterados por ele mesmo. Todos os campos vazios removem os dados.
+// Retorna os dados mascarados.
+func (s *DadosBancariosService) Atualizar(colaboradorID int, dados model.DadosBancarios) (*model.DadosBancarios, error) {
+	dados, err := normalizarDadosBancarios(dados)
+	if err != nil {
+		return nil, err
+	}
+
+	if dados == (model.DadosBancarios{}) {
+		return nil, s.colaboradorRepo.UpdateDadosBancarios(colaboradorID, nil, colaboradorID)
+	}
+
+	texto, err := json.Marshal(dados)
+	if err != nil {
+		return nil, err
+	}
+
+	cifrados, err := s.chaveiro.Cifrar(texto, contextoDadosBancarios(colaboradorID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.colaboradorRepo.UpdateDadosBancarios(colaboradorID, cifrados, colaboradorID); err != nil {
+		return nil, err
+	}
+
+	return mascararDadosBancarios(&dados), nil
+}
+
+// Revelar retorna os dados completos. A consulta é registrada antes de os
+// dados serem decifrados; sem o registro, nada é revelado.
+func (s *DadosBancariosService) Revelar(colaboradorID, reveladoPor int, motivo, ip string) (*model.DadosBancarios, error) {
+	motivo = strings.TrimSpace(motivo)
+	if motivo == "" {
+		return nil, ErrMotivoObrigatorio
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	if len(colaborador.DadosBancariosCifrados) == 0 {
+		return nil, ErrDadosBancariosNaoCadastrados
+	}
+
+	revelacao := &model.RevelacaoDadosBancarios{
+		ColaboradorID: colaboradorID,
+		ReveladoPor:   &reveladoPor,
+		Motivo:        motivo,
+		IP:            ip,
+	}
+	if err := s.revelacaoRepo.Create(revelacao); err != nil {
+		return nil, err
+	}
+
+	dados, err := s.abrir(colaboradorID, colaborador.DadosBancariosCifrados)
+	if err != nil {
+		log.Printf("Erro ao decifrar dados bancários do colaborador %d: %v", colaboradorID, err)
+		return nil, ErrDadosBancariosIndisponiveis
+	}
+
+	return dados, nil
+}
+
+// RevelarProprios retorna os dados completos do próprio colaborador
+func (s *DadosBancariosService) RevelarProprios(colaboradorID int, ip string) (*model.DadosBancarios, error) {
+	return s.Revelar(colaboradorID, colaboradorID, motivoConsultaPropria, ip)
+}
+
+// ListRevelacoes retorna quem consultou os dados completos do colaborador
+func (s *DadosBancariosService) ListRevelacoes(colaboradorID, limit, offset int) ([]*model.RevelacaoDadosBancarios, error) {
+	if limit <= 0 || limit > maxRevelacoesPorPagina {
+		limit = maxRevelacoesPorPagina
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.revelacaoRepo.ListByColaborador(colaboradorID, limit, offset)
+}
+
+// Rotacionar cifra os dados bancários antigos, gravados em texto puro, e
+// reembrulha com a chave mestra ativa os envelopes que usam outra chave.
+// Depois de concluída, as chaves mestras antigas podem sair do arquivo.
+// Retorna quantos registros foram regravados.
+func (s *DadosBancariosService) Rotacionar() (int, error) {
+	registros, err := s.colaboradorRepo.ListDadosBancarios()
+	if err != nil {
+		return 0, err
+	}
+
+	regravados := 0
+	for id, anteriores := range registros {
+		if len(anteriores) == 0 {
+			continue
+		}
+
+		var novos []byte
+		if envelope.EhEnvelope(anteriores) {
+			var alterado bool
+			novos, alterado, err = s.chaveiro.Reembrulhar(anteriores)
+			if err == nil && !alterado {
+				continue
+			}
+		} else {
+			novos, err = s.chaveiro.Cifrar(anteriores, contextoDadosBancarios(id))
+		}
+		if err != nil {
+			log.Printf("Erro ao cifrar dados bancários do colaborador %d: %v", id, err)
+			continue
+		}
+
+		// Se o colaborador alterou os dados nesse meio tempo, eles já foram
+		// gravados com a chave ativa
+		ok, err := s.colaboradorRepo.ReplaceDadosBancarios(id, anteriores, novos)
+		if err != nil {
+			log.Printf("Erro ao regravar dados bancários do colaborador %d: %v", id, err)
+			continue
+		}
+		if ok {
+			regravados++
+		}
+	}
+
+	return regravados, nil
+}
+
+// abrir decifra os dados gravados. Registros antigos, ainda não cifrados por
+// Rotacionar, são lidos diretamente.
+func (s *DadosBancariosService) abrir(colaboradorID int, gravados []byte) (*model.DadosBancarios, error) {
+	if len(gravados) == 0 {
+		return nil, nil
+	}
+
+	texto := gravados
+	if envelope.EhEnvelope(gravados) {
+		var err error
+		texto, err = s.chaveiro.Decifrar(gravados, contextoDadosBancarios(colaboradorID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dados := &model.DadosBancarios{}
+	if err := json.Unmarshal(texto, dados); err != nil {
+		return nil, fmt.Errorf("dados bancários fora do formato esperado: %w", err)
+	}
+
+	return dados, nil
+}
+
+// contextoDadosBancarios vincula o envelope ao colaborador, impedindo que os
+// dados cifrados de um sejam copiados para outro
+func contextoDadosBancarios(colaboradorID int) []byte {
+	return []byte(fmt.Sprintf("usuarios.dados_bancarios:%d", colaboradorID))
+}
+
+// normalizarDadosBancarios remove espaços e pontuação dispensável e valida
+// cada campo
+func normalizarDadosBancarios(dados model.DadosBancarios) (model.DadosBancarios, error) {
+	dados.Banco = strings.TrimSpace(dados.Banco)
+	dados.Agencia = strings.ToUpper(strings.Join(strings.Fields(dados.Agencia), ""))
+	dados.Conta = strings.ToUpper(strings.ReplaceAll(strings.Join(strings.Fields(dados.Conta), ""), ".", ""))
+	dados.ChavePix = strings.TrimSpace(dados.ChavePix)
+
+	conta := dados.Banco != "" || dados.Agencia != "" || dados.Conta != ""
+	if conta {
+		if dados.Banco == "" || dados.Agencia == "" || dados.Conta == "" {
+			return dados, ErrDadosBancariosIncompletos
+		}
+		if !regexBanco.MatchString(dados.Banco) {
+			return dados, ErrBancoInvalido
+		}
+		if !regexAgencia.MatchString(dados.Agencia) {
+			return dados, ErrAgenciaInvalida
+		}
+		if !regexConta.MatchString(dados.Conta) {
+			return dados, ErrContaInvalida
+		}
+	}
+
+	if dados.ChavePix != "" {
+		chave, ok := normalizarChavePix(dados.ChavePix)
+		if !ok {
+			return dados, ErrChavePixInvalida
+		}
+		dados.ChavePix = chave
+	}
+
+	return dados, nil
+}
+
+// normalizarChavePix reconhece o tipo da chave e a deixa no formato do PIX
+func normalizarChavePix(chave string) (string, bool) {
+	if regexPixEmail.MatchString(chave) {
+		return strings.ToLower(chave), true
+	}
+	if regexPixAleatoria.MatchString(strings.ToLower(chave)) {
+		return strings.ToLower(chave), true
+	}
+	if strings.HasPrefix(chave, "+") {
+		telefone := "+" + apenasDigitos(chave)
+		return telefone, regexPixTelefone.MatchString(telefone)
+	}
+
+	documento := apenasDigitos(chave)
+	return documento, regexPixDocumento.MatchString(documento)
+}
+
+func apenasDigitos(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// mascararDadosBancarios mantém o banco e os dois últimos caracteres de
+// agência, conta e chave PIX; de emails, mantém também o domínio
+func mascararDadosBancarios(dados *model.DadosBancarios) *model.DadosBancarios {
+	if dados == nil {
+		return nil
+	}
+
+	mascarados := &model.DadosBancarios{
+		Banco:   dados.Banco,
+		Agencia: mascarar(dados.Agencia, 2),
+		Conta:   mascarar(dados.Conta, 2),
+	}
+
+	if usuario, dominio, ok := strings.Cut(dados.ChavePix, "@"); ok {
+		mascarados.ChavePix = mascarar(usuario, 1) + "@" + dominio
+	} else {
+		mascarados.ChavePix = mascarar(dados.ChavePix, 2)
+	}
+
+	return mascarados
+}
+
+// mascarar troca por * as letras e dígitos, exceto os últimos visiveis;
+// separadores como - e @ são mantidos
+func mascarar(s string, visiveis int) string {
+	runes := []rune(s)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			continue
+		}
+		if visiveis > 0 {
+			visiveis--
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+var (
+	ErrDepartamentoNaoEncontrado = errors.New("departamento não encontrado")
+	ErrDepartamentoNomeEmUso     = errors.New("já existe um departamento com este nome")
+	ErrDepartamentoEmUso         = errors.New("departamento já teve colaboradores alocados; desative-o em vez de excluir")
+)
+
+// DepartamentoService mantém o cadastro de departamentos. Departamentos
+// inativos continuam no histórico de alocações, mas não recebem novas.
+type DepartamentoService struct {
+	departamentoRepo *repository.DepartamentoRepository
+}
+
+func NewDepartamentoService(departamentoRepo *repository.DepartamentoRepository) *DepartamentoService {
+	return &DepartamentoService{departamentoRepo: departamentoRepo}
+}
+
+func (s *DepartamentoService) List(incluirInativos bool) ([]*model.Departamento, error) {
+	return s.departamentoRepo.List(incluirInativos)
+}
+
+func (s *DepartamentoService) GetByID(id int) (*model.Departamento, error) {
+	departamento, err := s.departamentoRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrDepartamentoNaoEncontrado
+	}
+	return departamento, nil
+}
+
+func (s *DepartamentoService) Create(req model.DepartamentoRequest) (*model.Departamento, error) {
+	departamento := &model.Departamento{Ativo: true}
+	if err := s.aplicar(departamento, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.departamentoRepo.Create(departamento); err != nil {
+		return nil, err
+	}
+
+	return departamento, nil
+}
+
+func (s *DepartamentoService) Update(id int, req model.DepartamentoRequest) (*model.Departamento, error) {
+	departamento, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.aplicar(departamento, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.departamentoRepo.Update(departamento); err != nil {
+		return nil, err
+	}
+
+	return departamento, nil
+}
+
+// Delete exclui o departamento, desde que nunca tenha tido colaboradores
+func (s *DepartamentoService) Delete(id int) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+
+	excluido, err := s.departamentoRepo.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !excluido {
+		return ErrDepartamentoEmUso
+	}
+
+	return nil
+}
+
+func (s *DepartamentoService) aplicar(departamento *model.Departamento, req model.DepartamentoRequest) error {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return ErrNomeObrigatorio
+	}
+
+	existe, err := s.departamentoRepo.ExistsNome(nome, departamento.ID)
+	if err != nil {
+		return err
+	}
+	if existe {
+		return ErrDepartamentoNomeEmUso
+	}
+
+	departamento.Nome = nome
+	departamento.Descricao = strings.TrimSpace(req.Descricao)
+	if req.Ativo != nil {
+		departamento.Ativo = *req.Ativo
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// Resultado de cada item do checklist de desligamento
+const (
+	ChecklistConcluido    = "concluido"
+	ChecklistNaoAplicavel = "nao_aplicavel"
+	ChecklistFalhou       = "falhou"
+)
+
+var (
+	ErrColaboradorJaDesligado    = errors.New("colaborador já está desligado")
+	ErrDesligamentoProprio       = errors.New("não é possível desligar a si mesmo")
+	ErrDesligamentoDataInvalida  = errors.New("data de desligamento inválida: use AAAA-MM-DD")
+	ErrDesligamentoDataFutura    = errors.New("a data de desligamento não pode ser futura")
+	ErrDesligamentoAntesAdmissao = errors.New("a data de desligamento não pode ser anterior à admissão")
+	ErrPontoDesdeInvalido        = errors.New("início do espelho de ponto inválido: use AAAA-MM-DD, até a data de desligamento")
+	ErrSubstitutoNaoEncontrado   = errors.New("substituto não encontrado")
+	ErrSubstitutoDesligado       = errors.New("o substituto está desligado")
+	ErrSubstitutoProprio         = errors.New("o colaborador não pode ser o próprio substituto")
+	ErrSubstitutoAbaixo          = errors.New("o substituto está abaixo dos subordinados do colaborador na hierarquia")
+)
+
+// DesligamentoService conduz o desligamento de um colaborador e registra o
+// checklist do que foi feito
+type DesligamentoService struct {
+	desligamentoRepo *repository.DesligamentoRepository
+	colaboradorRepo  *repository.ColaboradorRepository
+	status           *StatusColaboradorService
+}
+
+func NewDesligamentoService(
+	desligamentoRepo *repository.DesligamentoRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	status *StatusColaboradorService,
+) *DesligamentoService {
+	return &DesligamentoService{
+		desligamentoRepo: desligamentoRepo,
+		colaboradorRepo:  colaboradorRepo,
+		status:           status,
+	}
+}
+
+// Desligar desliga o colaborador: as alterações no banco são feitas em uma
+// única transação (ver DesligamentoRepository.Desligar) e, depois dela, as
+// sessões são encerradas. O relatório retornado indica o resultado de cada
+// etapa; uma falha ao encerrar as sessões aparece no checklist e pode ser
+// refeita pela revogação de tokens.
+func (s *DesligamentoService) Desligar(colaboradorID int, req model.DesligamentoRequest, realizadoPor int) (*model.Desligamento, error) {
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	if colaborador.Status == StatusDesligado {
+		return nil, ErrColaboradorJaDesligado
+	}
+	if colaboradorID == realizadoPor {
+		return nil, ErrDesligamentoProprio
+	}
+
+	motivo := strings.TrimSpace(req.Motivo)
+	if motivo == "" {
+		return nil, ErrMotivoObrigatorio
+	}
+
+	hoje := truncarDia(time.Now())
+	data := hoje
+	if req.DataDesligamento != "" {
+		if data, err = time.Parse("2006-01-02", req.DataDesligamento); err != nil {
+			return nil, ErrDesligamentoDataInvalida
+		}
+	}
+	if data.After(hoje) {
+		return nil, ErrDesligamentoDataFutura
+	}
+	if !colaborador.DataAdmissao.IsZero() && data.Before(truncarDia(colaborador.DataAdmissao)) {
+		return nil, ErrDesligamentoAntesAdmissao
+	}
+
+	pontoDesde := time.Date(data.Year(), data.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if req.PontoDesde != "" {
+		if pontoDesde, err = time.Parse("2006-01-02", req.PontoDesde); err != nil || pontoDesde.After(data) {
+			return nil, ErrPontoDesdeInvalido
+		}
+	}
+
+	var substituto *model.Colaborador
+	if req.SubstitutoID != nil {
+		if *req.SubstitutoID == colaboradorID {
+			return nil, ErrSubstitutoProprio
+		}
+		if substituto, err = s.colaboradorRepo.GetByID(*req.SubstitutoID); err != nil {
+			return nil, ErrSubstitutoNaoEncontrado
+		}
+		if substituto.Status == StatusDesligado {
+			return nil, ErrSubstitutoDesligado
+		}
+	}
+
+	desligamento := &model.Desligamento{
+		ColaboradorID:    colaboradorID,
+		DataDesligamento: data,
+		Motivo:           motivo,
+		SubstitutoID:     req.SubstitutoID,
+	}
+	if realizadoPor != 0 {
+		desligamento.RealizadoPor = &realizadoPor
+	}
+
+	resultado, aplicado, err := s.desligamentoRepo.Desligar(desligamento, req.RejeitarDocumentosPendentes, pontoDesde.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	if !aplicado {
+		// O status já foi conferido acima; salvo desligamento simultâneo, o
+		// impedimento é a posição do substituto na hierarquia
+		if atual, err := s.colaboradorRepo.GetByID(colaboradorID); err == nil && atual.Status == StatusDesligado {
+			return nil, ErrColaboradorJaDesligado
+		}
+		return nil, ErrSubstitutoAbaixo
+	}
+
+	log.Printf("Colaborador %d desligado por %d", colaboradorID, realizadoPor)
+
+	sessoes := &model.ItemChecklistDesligamento{
+		Item:    "sessoes",
+		Status:  ChecklistConcluido,
+		Detalhe: "sessões, tokens de acesso e refresh tokens revogados",
+	}
+	if err := s.status.EncerrarAcesso(colaboradorID); err != nil {
+		log.Printf("Erro ao encerrar as sessões do colaborador desligado %d: %v", colaboradorID, err)
+		sessoes.Status = ChecklistFalhou
+		sessoes.Detalhe = "erro ao encerrar as sessões; revogue os tokens do colaborador manualmente: " + err.Error()
+	}
+
+	desligamento.EspelhoPonto = montarEspelhoPonto(resultado.RegistrosPonto, pontoDesde, data)
+	desligamento.Checklist = checklistDesligamento(resultado, sessoes, colaborador, substituto, desligamento.EspelhoPonto)
+
+	// O desligamento já está gravado; sem o relatório, ele ainda é retornado
+	if err := s.desligamentoRepo.SalvarRelatorio(desligamento.ID, desligamento.Checklist, desligamento.EspelhoPonto); err != nil {
+		log.Printf("Erro ao salvar o relatório do desligamento %d: %v", desligamento.ID, err)
+	}
+
+	return desligamento, nil
+}
+
+// List retorna os desligamentos do colaborador com os relatórios
+func (s *DesligamentoService) List(colaboradorID int) ([]*model.Desligamento, error) {
+	if _, err := s.colaboradorRepo.GetByID(colaboradorID); err != nil {
+		return nil, ErrColaboradorNaoEncontrado
+	}
+	return s.desligamentoRepo.ListByColaborador(colaboradorID)
+}
+
+// checklistDesligamento descreve as etapas do desligamento, na ordem em que
+// foram feitas
+func checklistDesligamento(resultado *repository.ResultadoDesligamento, sessoes *model.ItemChecklistDesligamento, colaborador, substituto *model.Colaborador, espelho *model.EspelhoPonto) []*model.ItemChecklistDesligamento {
+	item := func(nome string, feito bool, detalhe string) *model.ItemChecklistDesligamento {
+		status := ChecklistConcluido
+		if !feito {
+			status = ChecklistNaoAplicavel
+		}
+		return &model.ItemChecklistDesligamento{Item: nome, Status: status, Detalhe: detalhe}
+	}
+
+	novoGestor := "sem gestor"
+	if substituto != nil {
+		novoGestor = substituto.Nome
+	} else if colaborador.GestorNome != "" {
+		novoGestor = colaborador.GestorNome
+	}
+
+	documentos := item("documentos_enviados", resultado.DocumentosMantidos > 0,
+		fmt.Sprintf("%d documentos pendentes mantidos para o acerto final", resultado.DocumentosMantidos))
+	if resultado.DocumentosRejeitados > 0 {
+		documentos = item("documentos_enviados", true,
+			fmt.Sprintf("%d documentos pendentes rejeitados", resultado.DocumentosRejeitados))
+	}
+
+	alocacao := item("alocacao", resultado.AlocacaoEncerrada, "sem alocação vigente")
+	if resultado.AlocacaoEncerrada {
+		alocacao.Detalhe = "alocação encerrada em " + espelho.Fim
+	}
+
+	return []*model.ItemChecklistDesligamento{
+		item("status", true, fmt.Sprintf("status alterado de %s para %s", resultado.StatusAnterior, StatusDesligado)),
+		sessoes,
+		item("subordinados", resultado.Subordinados > 0,
+			fmt.Sprintf("%d subordinados diretos transferidos para %s", resultado.Subordinados, novoGestor)),
+		item("aprovacoes_pendentes", resultado.AprovacoesReencaminhadas > 0,
+			fmt.Sprintf("%d documentos aguardando aprovação reencaminhados ao novo gestor dos autores (sem gestor, ao RH)", resultado.AprovacoesReencaminhadas)),
+		documentos,
+		alocacao,
+		item("espelho_ponto", true,
+			fmt.Sprintf("%d registros de ponto de %s a %s salvos para o acerto final", len(espelho.Registros), espelho.Inicio, espelho.Fim)),
+	}
+}
+
+// montarEspelhoPonto agrupa os registros por dia e soma os minutos entre
+// entrada (ou fim do intervalo) e saída (ou início do intervalo)
+func montarEspelhoPonto(registros []*model.RegistroPonto, inicio, fim time.Time) *model.EspelhoPonto {
+	espelho := &model.EspelhoPonto{
+		Inicio:    inicio.Format("2006-01-02"),
+		Fim:       fim.Format("2006-01-02"),
+		Dias:      []*model.EspelhoPontoDia{},
+		Registros: registros,
+	}
+
+	var dia *model.EspelhoPontoDia
+	var abertoEm time.Time
+	aberto := false
+
+	fecharDia := func() {
+		if dia == nil {
+			return
+		}
+		if aberto {
+			dia.Inconsistente = true
+		}
+		espelho.TotalMinutos += dia.MinutosTrabalhados
+	}
+
+	for _, registro := range registros {
+		data := registro.DataHora.Format("2006-01-02")
+		if dia == nil || dia.Data != data {
+			fecharDia()
+			dia = &model.EspelhoPontoDia{Data: data}
+			espelho.Dias = append(espelho.Dias, dia)
+			aberto = false
+		}
+		dia.Registros++
+
+		switch registro.Tipo {
+		case "entrada", "intervalo_fim":
+			if aberto {
+				dia.Inconsistente = true
+			}
+			aberto, abertoEm = true, registro.DataHora
+		case "saida", "intervalo_inicio":
+			if !aberto {
+				dia.Inconsistente = true
+				continue
+			}
+			dia.MinutosTrabalhados += int(registro.DataHora.Sub(abertoEm).Minutes())
+			aberto = false
+		}
+	}
+	fecharDia()
+
+	return espelho
+}
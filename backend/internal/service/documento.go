@@ -6,12 +6,36 @@ import (
 	"errors"
 )
 
+var (
+	ErrDocumentoNaoEncontrado  = errors.New("documento não encontrado")
+	ErrDocumentoJaDecidido     = errors.New("documento já foi aprovado ou rejeitado")
+	ErrDocumentoProprio        = errors.New("não é possível aprovar ou rejeitar o próprio documento")
+	ErrDocumentoOutroAprovador = errors.New("documento aguarda a decisão do gestor de quem o enviou")
+	ErrDocumentoSem2FA         = errors.New("ative a verificação em duas etapas para aprovar ou rejeitar documentos")
+)
+
+// Tamanho máximo de uma página da fila de aprovação
+const maxPendentesPorPagina = 100
+
+// DocumentoService guarda os documentos enviados e encaminha os pendentes
+// para aprovação: primeiro o gestor direto de quem enviou e, sem gestor ou
+// com o gestor fora de atividade (férias, afastamento, desligamento), o RH
 type DocumentoService struct {
-	documentoRepo *repository.DocumentoRepository
+	documentoRepo   *repository.DocumentoRepository
+	colaboradorRepo *repository.ColaboradorRepository
+	doisFatores     *DoisFatoresService
 }
 
-func NewDocumentoService(documentoRepo *repository.DocumentoRepository) *DocumentoService {
-	return &DocumentoService{documentoRepo: documentoRepo}
+func NewDocumentoService(
+	documentoRepo *repository.DocumentoRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	doisFatores *DoisFatoresService,
+) *DocumentoService {
+	return &DocumentoService{
+		documentoRepo:   documentoRepo,
+		colaboradorRepo: colaboradorRepo,
+		doisFatores:     doisFatores,
+	}
 }
 
 func (s *DocumentoService) Create(documento *model.Documento) error {
@@ -24,6 +48,12 @@ func (s *DocumentoService) Create(documento *model.Documento) error {
 		return errors.New("tipo de documento é obrigatório")
 	}
 
+	colaborador, err := s.colaboradorRepo.GetByID(documento.ColaboradorID)
+	if err != nil {
+		return err
+	}
+	documento.AprovadorID = colaborador.GestorID
+
 	return s.documentoRepo.Create(documento)
 }
 
@@ -31,8 +61,8 @@ func (s *DocumentoService) GetByID(id int) (*model.Documento, error) {
 	return s.documentoRepo.GetByID(id)
 }
 
-func (s *DocumentoService) List(colaboradorID *int, status string, limit, offset int) ([]*model.Documento, error) {
-	return s.documentoRepo.List(colaboradorID, status, limit, offset)
+func (s *DocumentoService) List(filtro model.DocumentoFiltro) ([]*model.Documento, error) {
+	return s.documentoRepo.List(filtro)
 }
 
 func (s *DocumentoService) UpdateStatus(id int, status string, aprovadoPor int) error {
@@ -44,6 +74,84 @@ func (s *DocumentoService) UpdateStatus(id int, status string, aprovadoPor int)
 	return s.documentoRepo.UpdateStatus(id, status, aprovadoPor)
 }
 
+// Decidir aprova ou rejeita um documento pendente. Pode decidir o gestor a
+// quem o documento foi encaminhado; o RH (rh) decide os documentos sem gestor
+// ou cujo gestor não está ativo. Como qualquer gestor direto vira aprovador,
+// sem que o cargo exija 2FA, a decisão exige o 2FA ativo, o que garante que o
+// login foi feito com o segundo fator.
+func (s *DocumentoService) Decidir(id int, status string, colaboradorID int, rh bool) error {
+	if status != "aprovado" && status != "rejeitado" {
+		return errors.New("status inválido")
+	}
+
+	doc, err := s.documentoRepo.GetByID(id)
+	if err != nil {
+		return ErrDocumentoNaoEncontrado
+	}
+
+	if doc.Status != "pendente" {
+		return ErrDocumentoJaDecidido
+	}
+	if doc.ColaboradorID == colaboradorID {
+		return ErrDocumentoProprio
+	}
+	if !s.podeDecidir(doc, colaboradorID, rh) {
+		return ErrDocumentoOutroAprovador
+	}
+
+	ativo, err := s.doisFatores.Ativo(colaboradorID)
+	if err != nil {
+		return err
+	}
+	if !ativo {
+		return ErrDocumentoSem2FA
+	}
+
+	decidido, err := s.documentoRepo.Decide(id, status, colaboradorID)
+	if err != nil {
+		return err
+	}
+	if !decidido {
+		return ErrDocumentoJaDecidido
+	}
+
+	return nil
+}
+
+// PodeVer informa se o colaborador pode ver o documento por ser quem o
+// enviou ou o aprovador a quem ele foi encaminhado
+func (s *DocumentoService) PodeVer(doc *model.Documento, colaboradorID int) bool {
+	return doc.ColaboradorID == colaboradorID || (doc.AprovadorID != nil && *doc.AprovadorID == colaboradorID)
+}
+
+// ListPendentesAprovacao retorna a fila de documentos que aguardam a decisão
+// do colaborador
+func (s *DocumentoService) ListPendentesAprovacao(colaboradorID int, rh bool, limit, offset int) ([]*model.Documento, error) {
+	if limit <= 0 || limit > maxPendentesPorPagina {
+		limit = maxPendentesPorPagina
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.documentoRepo.ListPendentesAprovacao(colaboradorID, rh, limit, offset)
+}
+
+func (s *DocumentoService) podeDecidir(doc *model.Documento, colaboradorID int, rh bool) bool {
+	if doc.AprovadorID == nil {
+		return rh
+	}
+	if *doc.AprovadorID == colaboradorID {
+		return true
+	}
+	if !rh {
+		return false
+	}
+
+	status, err := s.colaboradorRepo.GetStatus(*doc.AprovadorID)
+	return err != nil || status != StatusAtivo
+}
+
 func (s *DocumentoService) MarkAsSent(id int, observacoes string) error {
 	// Verificar se documento existe e está aprovado
 	doc, err := s.documentoRepo.GetByID(id)
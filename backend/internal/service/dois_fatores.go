@@ -0,0 +1,211 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/totp"
+)
+
+const (
+	doisFatoresIssuer            = "RLS Automação"
+	quantidadeCodigosRecuperacao = 10
+)
+
+var ErrCodigo2FAInvalido = errors.New("código de verificação inválido")
+
+type DoisFatoresService struct {
+	doisFatoresRepo *repository.DoisFatoresRepository
+	colaboradorRepo *repository.ColaboradorRepository
+	cargoRepo       *repository.CargoRepository
+}
+
+func NewDoisFatoresService(
+	doisFatoresRepo *repository.DoisFatoresRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	cargoRepo *repository.CargoRepository,
+) *DoisFatoresService {
+	return &DoisFatoresService{
+		doisFatoresRepo: doisFatoresRepo,
+		colaboradorRepo: colaboradorRepo,
+		cargoRepo:       cargoRepo,
+	}
+}
+
+// Exigido informa se a política do cargo torna o 2FA obrigatório
+func (s *DoisFatoresService) Exigido(cargoID int) (bool, error) {
+	cargo, err := s.cargoRepo.GetByID(cargoID)
+	if err != nil {
+		return false, err
+	}
+	return cargo.Exige2FA, nil
+}
+
+// Ativo informa se o colaborador já concluiu o cadastro do 2FA. Apenas a
+// ausência de cadastro conta como 2FA inativo; outras falhas são retornadas
+// para que o login não dispense o segundo fator por engano.
+func (s *DoisFatoresService) Ativo(colaboradorID int) (bool, error) {
+	config, err := s.doisFatoresRepo.GetByColaborador(colaboradorID)
+	if errors.Is(err, repository.ErrDoisFatoresNaoConfigurado) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return config.AtivadoEm != nil, nil
+}
+
+// IniciarCadastro gera um novo segredo pendente de ativação
+func (s *DoisFatoresService) IniciarCadastro(colaboradorID int) (*model.DoisFatoresCadastroResponse, error) {
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+
+	segredo, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.doisFatoresRepo.SaveSecret(colaboradorID, segredo); err != nil {
+		return nil, err
+	}
+
+	return &model.DoisFatoresCadastroResponse{
+		Segredo: segredo,
+		URI:     totp.URI(doisFatoresIssuer, colaborador.Email, segredo),
+	}, nil
+}
+
+// Ativar confirma o cadastro com um código do autenticador e gera os códigos
+// de recuperação, retornados em texto puro uma única vez
+func (s *DoisFatoresService) Ativar(colaboradorID int, codigo string) ([]string, error) {
+	config, err := s.doisFatoresRepo.GetByColaborador(colaboradorID)
+	if err != nil {
+		return nil, errors.New("cadastro de 2FA não iniciado")
+	}
+
+	if config.AtivadoEm != nil {
+		return nil, errors.New("2FA já está ativo")
+	}
+
+	if err := s.verificarTOTP(config, codigo); err != nil {
+		return nil, err
+	}
+
+	codigos := make([]string, 0, quantidadeCodigosRecuperacao)
+	hashes := make([]string, 0, quantidadeCodigosRecuperacao)
+	for i := 0; i < quantidadeCodigosRecuperacao; i++ {
+		codigo, err := gerarCodigoRecuperacao()
+		if err != nil {
+			return nil, err
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(codigo), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+
+		codigos = append(codigos, codigo)
+		hashes = append(hashes, string(hash))
+	}
+
+	if err := s.doisFatoresRepo.Activate(colaboradorID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codigos, nil
+}
+
+// Verificar valida um código TOTP ou, alternativamente, um código de recuperação
+func (s *DoisFatoresService) Verificar(colaboradorID int, codigo string) error {
+	config, err := s.doisFatoresRepo.GetByColaborador(colaboradorID)
+	if err != nil || config.AtivadoEm == nil {
+		return errors.New("2FA não está ativo")
+	}
+
+	codigo = strings.TrimSpace(codigo)
+	if len(codigo) == totp.Digits {
+		return s.verificarTOTP(config, codigo)
+	}
+
+	return s.verificarCodigoRecuperacao(colaboradorID, codigo)
+}
+
+// Desativar remove o 2FA, desde que o cargo não o torne obrigatório
+func (s *DoisFatoresService) Desativar(colaboradorID, cargoID int, codigo string) error {
+	exigido, err := s.Exigido(cargoID)
+	if err != nil {
+		return err
+	}
+	if exigido {
+		return errors.New("2FA é obrigatório para o seu cargo")
+	}
+
+	if err := s.Verificar(colaboradorID, codigo); err != nil {
+		return err
+	}
+
+	return s.doisFatoresRepo.Delete(colaboradorID)
+}
+
+func (s *DoisFatoresService) verificarTOTP(config *model.DoisFatores, codigo string) error {
+	passo, ok := totp.Validate(config.Segredo, codigo, time.Now())
+	if !ok {
+		return ErrCodigo2FAInvalido
+	}
+
+	// Impedir reuso do mesmo código (ou de um código mais antigo)
+	aceito, err := s.doisFatoresRepo.UpdateUltimoPasso(config.ColaboradorID, passo)
+	if err != nil {
+		return err
+	}
+	if !aceito {
+		return ErrCodigo2FAInvalido
+	}
+
+	return nil
+}
+
+func (s *DoisFatoresService) verificarCodigoRecuperacao(colaboradorID int, codigo string) error {
+	codigos, err := s.doisFatoresRepo.ListUnusedRecoveryCodes(colaboradorID)
+	if err != nil {
+		return err
+	}
+
+	codigo = strings.ToUpper(codigo)
+	for _, c := range codigos {
+		if bcrypt.CompareHashAndPassword([]byte(c.CodigoHash), []byte(codigo)) != nil {
+			continue
+		}
+
+		consumido, err := s.doisFatoresRepo.MarkRecoveryCodeUsed(c.ID)
+		if err != nil {
+			return err
+		}
+		if !consumido {
+			return ErrCodigo2FAInvalido
+		}
+		return nil
+	}
+
+	return ErrCodigo2FAInvalido
+}
+
+// gerarCodigoRecuperacao gera um código no formato XXXXX-XXXXX (50 bits)
+func gerarCodigoRecuperacao() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b)[:10]
+	return encoded[:5] + "-" + encoded[5:], nil
+}
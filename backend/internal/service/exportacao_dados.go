@@ -0,0 +1,446 @@
+package service
+
+import (
+	"archive/zip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// Status de uma exportação de dados
+const (
+	ExportacaoProcessando = "processando"
+	ExportacaoConcluida   = "concluida"
+	ExportacaoFalhou      = "falhou"
+	ExportacaoExpirada    = "expirada"
+)
+
+const (
+	// Por quanto tempo o link de download vale depois de gerado o arquivo
+	validadeExportacao = 24 * time.Hour
+	// Exportação em andamento há mais tempo que isso foi interrompida (ex.:
+	// reinício do servidor) e pode ser pedida de novo
+	limiteProcessamentoExportacao = time.Hour
+	// Exportações gerando ZIP ao mesmo tempo
+	maxExportacoesSimultaneas = 2
+	// Tamanho das páginas lidas do histórico
+	paginaExportacao = 500
+
+	motivoExportacaoDados = "exportação de dados pessoais (LGPD)"
+)
+
+var (
+	ErrExportacaoNaoEncontrada = errors.New("exportação não encontrada")
+	ErrExportacaoLinkInvalido  = errors.New("link de download inválido ou expirado")
+)
+
+const leiameExportacao = `Exportação dos seus dados pessoais (LGPD)
+
+perfil.json                  cadastro, cargo, gestor, dados bancários completos e alocações
+historico_login.json         tentativas de login com IP e navegador
+registros_ponto.json         todos os registros de ponto, com localização
+documentos.json              dados dos recibos e documentos enviados
+documentos/                  arquivos anexados aos documentos
+acessos_dados_bancarios.json quem consultou os seus dados bancários completos
+foto_perfil.jpg              foto de perfil, se houver
+
+Datas e horas estão no formato ISO 8601.
+`
+
+// ExportacaoDadosService gera, em segundo plano, o ZIP com os dados pessoais
+// do colaborador e o disponibiliza por um link assinado que expira
+type ExportacaoDadosService struct {
+	exportacaoRepo  *repository.ExportacaoDadosRepository
+	colaboradorRepo *repository.ColaboradorRepository
+	documentoRepo   *repository.DocumentoRepository
+	pontoRepo       *repository.PontoRepository
+	tentativaRepo   *repository.TentativaLoginRepository
+	alocacaoRepo    *repository.AlocacaoRepository
+	fotoRepo        *repository.FotoPerfilRepository
+	dadosBancarios  *DadosBancariosService
+	dir             string
+	chave           []byte
+	vagas           chan struct{}
+}
+
+func NewExportacaoDadosService(
+	exportacaoRepo *repository.ExportacaoDadosRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	documentoRepo *repository.DocumentoRepository,
+	pontoRepo *repository.PontoRepository,
+	tentativaRepo *repository.TentativaLoginRepository,
+	alocacaoRepo *repository.AlocacaoRepository,
+	fotoRepo *repository.FotoPerfilRepository,
+	dadosBancarios *DadosBancariosService,
+	dir string,
+	segredo string,
+) *ExportacaoDadosService {
+	chave := sha256.Sum256([]byte("exportacao-dados:" + segredo))
+	return &ExportacaoDadosService{
+		exportacaoRepo:  exportacaoRepo,
+		colaboradorRepo: colaboradorRepo,
+		documentoRepo:   documentoRepo,
+		pontoRepo:       pontoRepo,
+		tentativaRepo:   tentativaRepo,
+		alocacaoRepo:    alocacaoRepo,
+		fotoRepo:        fotoRepo,
+		dadosBancarios:  dadosBancarios,
+		dir:             dir,
+		chave:           chave[:],
+		vagas:           make(chan struct{}, maxExportacoesSimultaneas),
+	}
+}
+
+// Solicitar retorna a exportação atual do colaborador: a em andamento ou a
+// concluída cujo link ainda vale. Sem nenhuma delas, inicia uma nova em
+// segundo plano. O ip é registrado na consulta aos dados bancários.
+func (s *ExportacaoDadosService) Solicitar(colaboradorID int, ip string) (*model.ExportacaoDados, error) {
+	ultima, err := s.exportacaoRepo.GetUltima(colaboradorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ultima != nil {
+		switch {
+		case ultima.Status == ExportacaoProcessando && time.Since(ultima.CriadoEm) < limiteProcessamentoExportacao:
+			return ultima, nil
+		case ultima.Status == ExportacaoProcessando:
+			if err := s.exportacaoRepo.Falhar(ultima.ID, "exportação interrompida"); err != nil {
+				return nil, err
+			}
+		case ultima.Status == ExportacaoConcluida && ultima.ExpiraEm != nil && time.Now().Before(*ultima.ExpiraEm):
+			ultima.Link = s.link(ultima)
+			return ultima, nil
+		}
+	}
+
+	exportacao := &model.ExportacaoDados{ColaboradorID: colaboradorID}
+	criada, err := s.exportacaoRepo.Create(exportacao)
+	if err != nil {
+		return nil, err
+	}
+	if !criada {
+		// Outra requisição iniciou a exportação ao mesmo tempo
+		return s.exportacaoRepo.GetUltima(colaboradorID)
+	}
+
+	go s.gerar(exportacao.ID, colaboradorID, ip)
+
+	return exportacao, nil
+}
+
+// Arquivo confere a assinatura do link e retorna a exportação com o caminho
+// do ZIP
+func (s *ExportacaoDadosService) Arquivo(id int, expira, assinatura string) (*model.ExportacaoDados, error) {
+	if !hmac.Equal([]byte(assinatura), []byte(s.assinar(id, expira))) {
+		return nil, ErrExportacaoLinkInvalido
+	}
+
+	exportacao, err := s.exportacaoRepo.GetByID(id)
+	if err != nil {
+		return nil, ErrExportacaoNaoEncontrada
+	}
+
+	// A expiração assinada precisa ser a da exportação, que é conferida aqui
+	if exportacao.Status != ExportacaoConcluida || exportacao.ExpiraEm == nil ||
+		strconv.FormatInt(exportacao.ExpiraEm.Unix(), 10) != expira || !time.Now().Before(*exportacao.ExpiraEm) {
+		return nil, ErrExportacaoLinkInvalido
+	}
+
+	return exportacao, nil
+}
+
+// LimparExpiradas apaga os arquivos das exportações cujo link expirou.
+// Retorna quantas foram apagadas.
+func (s *ExportacaoDadosService) LimparExpiradas() (int, error) {
+	expiradas, err := s.exportacaoRepo.ListExpiradas(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	apagadas := 0
+	for _, exportacao := range expiradas {
+		if err := os.Remove(exportacao.CaminhoArquivo); err != nil && !os.IsNotExist(err) {
+			log.Printf("Erro ao apagar a exportação de dados %d: %v", exportacao.ID, err)
+			continue
+		}
+		if err := s.exportacaoRepo.Expirar(exportacao.ID); err != nil {
+			return apagadas, err
+		}
+		apagadas++
+	}
+
+	return apagadas, nil
+}
+
+func (s *ExportacaoDadosService) link(exportacao *model.ExportacaoDados) string {
+	expira := strconv.FormatInt(exportacao.ExpiraEm.Unix(), 10)
+	return fmt.Sprintf("/api/exportacoes/%d/arquivo?expira=%s&assinatura=%s", exportacao.ID, expira, s.assinar(exportacao.ID, expira))
+}
+
+func (s *ExportacaoDadosService) assinar(id int, expira string) string {
+	mac := hmac.New(sha256.New, s.chave)
+	fmt.Fprintf(mac, "%d:%s", id, expira)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *ExportacaoDadosService) gerar(id, colaboradorID int, ip string) {
+	s.vagas <- struct{}{}
+	defer func() { <-s.vagas }()
+
+	caminho, tamanho, err := s.montarZip(colaboradorID, ip)
+	if err != nil {
+		log.Printf("Erro ao gerar a exportação de dados %d do colaborador %d: %v", id, colaboradorID, err)
+		if err := s.exportacaoRepo.Falhar(id, "erro ao gerar o arquivo; tente novamente"); err != nil {
+			log.Printf("Erro ao registrar a falha da exportação de dados %d: %v", id, err)
+		}
+		return
+	}
+
+	if err := s.exportacaoRepo.Concluir(id, caminho, tamanho, time.Now().Add(validadeExportacao)); err != nil {
+		log.Printf("Erro ao concluir a exportação de dados %d: %v", id, err)
+		os.Remove(caminho)
+		return
+	}
+
+	log.Printf("Exportação de dados %d do colaborador %d concluída (%d bytes)", id, colaboradorID, tamanho)
+}
+
+// registroPontoExportado troca a localização (JSON gravado em bytes) pelo
+// próprio JSON, em vez de base64
+type registroPontoExportado struct {
+	*model.RegistroPonto
+	Localizacao json.RawMessage `json:"localizacao"`
+}
+
+// documentoExportado indica onde está o arquivo do documento dentro do ZIP
+type documentoExportado struct {
+	*model.Documento
+	Arquivo string `json:"arquivo"`
+}
+
+// montarZip grava o ZIP em um arquivo temporário e o renomeia ao final, para
+// que nunca exista um arquivo incompleto com o nome definitivo
+func (s *ExportacaoDadosService) montarZip(colaboradorID int, ip string) (string, int64, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return "", 0, err
+	}
+
+	caminho := filepath.Join(s.dir, uuid.New().String()+".zip")
+	temporario := caminho + ".tmp"
+
+	file, err := os.OpenFile(temporario, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(temporario)
+
+	if err := s.escreverZip(file, colaboradorID, ip); err != nil {
+		file.Close()
+		return "", 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return "", 0, err
+	}
+	if err := file.Close(); err != nil {
+		return "", 0, err
+	}
+
+	if err := os.Rename(temporario, caminho); err != nil {
+		return "", 0, err
+	}
+
+	return caminho, info.Size(), nil
+}
+
+func (s *ExportacaoDadosService) escreverZip(w io.Writer, colaboradorID int, ip string) error {
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+
+	escreverJSON := func(nome string, v interface{}) error {
+		f, err := zw.Create(nome)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	if err := zw.SetComment("Dados pessoais de " + colaborador.Nome); err != nil {
+		return err
+	}
+	if f, err := zw.Create("LEIAME.txt"); err != nil {
+		return err
+	} else if _, err := io.WriteString(f, leiameExportacao); err != nil {
+		return err
+	}
+
+	// Perfil, com os dados bancários completos (a consulta é registrada)
+	colaborador.Senha = ""
+	colaborador.DadosBancarios = nil
+	if len(colaborador.DadosBancariosCifrados) > 0 {
+		dados, err := s.dadosBancarios.Revelar(colaboradorID, colaboradorID, motivoExportacaoDados, ip)
+		if err != nil {
+			return err
+		}
+		colaborador.DadosBancarios = dados
+	}
+
+	alocacoes, err := s.alocacaoRepo.ListByColaborador(colaboradorID)
+	if err != nil {
+		return err
+	}
+
+	perfil := map[string]interface{}{
+		"colaborador": colaborador,
+		"alocacoes":   alocacoes,
+	}
+	if err := escreverJSON("perfil.json", perfil); err != nil {
+		return err
+	}
+
+	historico, err := s.historicoLogin(colaboradorID)
+	if err != nil {
+		return err
+	}
+	if err := escreverJSON("historico_login.json", historico); err != nil {
+		return err
+	}
+
+	registros, err := s.pontoRepo.ListByColaborador(colaboradorID)
+	if err != nil {
+		return err
+	}
+	pontos := make([]*registroPontoExportado, len(registros))
+	for i, registro := range registros {
+		pontos[i] = &registroPontoExportado{RegistroPonto: registro}
+		if json.Valid(registro.Localizacao) {
+			pontos[i].Localizacao = registro.Localizacao
+		}
+	}
+	if err := escreverJSON("registros_ponto.json", pontos); err != nil {
+		return err
+	}
+
+	documentos, err := s.documentos(zw, colaboradorID)
+	if err != nil {
+		return err
+	}
+	if err := escreverJSON("documentos.json", documentos); err != nil {
+		return err
+	}
+
+	revelacoes, err := s.revelacoes(colaboradorID)
+	if err != nil {
+		return err
+	}
+	if err := escreverJSON("acessos_dados_bancarios.json", revelacoes); err != nil {
+		return err
+	}
+
+	if foto, err := s.fotoRepo.Get(colaboradorID); err == nil {
+		if err := copiarParaZip(zw, "foto_perfil.jpg", foto.CaminhoGrande); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// documentos copia os anexos para o ZIP e retorna os dados dos documentos.
+// Anexos ausentes no armazenamento ficam com o campo arquivo vazio.
+func (s *ExportacaoDadosService) documentos(zw *zip.Writer, colaboradorID int) ([]*documentoExportado, error) {
+	lista, err := s.documentoRepo.List(model.DocumentoFiltro{ColaboradorID: &colaboradorID, Status: "todos"})
+	if err != nil {
+		return nil, err
+	}
+
+	documentos := make([]*documentoExportado, 0, len(lista))
+	for _, item := range lista {
+		doc, err := s.documentoRepo.GetByID(item.ID)
+		if err != nil {
+			return nil, err
+		}
+		exportado := &documentoExportado{Documento: doc}
+
+		if doc.CaminhoArquivo != "" {
+			nome := fmt.Sprintf("documentos/%d-%s%s", doc.ID, doc.UUID, filepath.Ext(doc.CaminhoArquivo))
+			if err := copiarParaZip(zw, nome, doc.CaminhoArquivo); err == nil {
+				exportado.Arquivo = nome
+			} else if !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+
+		documentos = append(documentos, exportado)
+	}
+
+	return documentos, nil
+}
+
+func (s *ExportacaoDadosService) historicoLogin(colaboradorID int) ([]*model.HistoricoLogin, error) {
+	historico := []*model.HistoricoLogin{}
+	for offset := 0; ; offset += paginaExportacao {
+		pagina, err := s.tentativaRepo.ListHistorico(colaboradorID, paginaExportacao, offset)
+		if err != nil {
+			return nil, err
+		}
+		historico = append(historico, pagina...)
+		if len(pagina) < paginaExportacao {
+			return historico, nil
+		}
+	}
+}
+
+func (s *ExportacaoDadosService) revelacoes(colaboradorID int) ([]*model.RevelacaoDadosBancarios, error) {
+	revelacoes := []*model.RevelacaoDadosBancarios{}
+	for offset := 0; ; offset += maxRevelacoesPorPagina {
+		pagina, err := s.dadosBancarios.ListRevelacoes(colaboradorID, maxRevelacoesPorPagina, offset)
+		if err != nil {
+			return nil, err
+		}
+		revelacoes = append(revelacoes, pagina...)
+		if len(pagina) < maxRevelacoesPorPagina {
+			return revelacoes, nil
+		}
+	}
+}
+
+// copiarParaZip adiciona o arquivo do armazenamento ao ZIP com o nome informado
+func copiarParaZip(zw *zip.Writer, nome, caminho string) error {
+	origem, err := os.Open(caminho)
+	if err != nil {
+		return err
+	}
+	defer origem.Close()
+
+	destino, err := zw.Create(nome)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(destino, origem)
+	return err
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/imagem"
+	"empresa-app/backend/pkg/storage"
+)
+
+// Lado, em pixels, das miniaturas quadradas geradas para cada foto
+const (
+	LadoFotoGrande  = 512
+	LadoFotoPequena = 128
+)
+
+var (
+	ErrFotoNaoEncontrada = errors.New("foto não encontrada")
+	ErrFotoGrande        = errors.New("arquivo muito grande")
+)
+
+// FotoPerfilService recebe a foto de perfil, gera as miniaturas sem metadados
+// e as guarda no armazenamento de arquivos
+type FotoPerfilService struct {
+	fotoRepo    *repository.FotoPerfilRepository
+	storage     *storage.FileStorage
+	maxFileSize int64
+}
+
+func NewFotoPerfilService(fotoRepo *repository.FotoPerfilRepository, fs *storage.FileStorage, maxFileSize int64) *FotoPerfilService {
+	return &FotoPerfilService{
+		fotoRepo:    fotoRepo,
+		storage:     fs,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// Enviar substitui a foto do colaborador e retorna a nova URL de foto_perfil
+func (s *FotoPerfilService) Enviar(colaboradorID int, arquivo io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(arquivo, s.maxFileSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return "", ErrFotoGrande
+	}
+
+	img, orientacao, err := imagem.Decodificar(data)
+	if err != nil {
+		return "", err
+	}
+
+	grande, err := imagem.CodificarJPEG(imagem.Miniatura(img, LadoFotoGrande, orientacao))
+	if err != nil {
+		return "", err
+	}
+	pequena, err := imagem.CodificarJPEG(imagem.Miniatura(img, LadoFotoPequena, orientacao))
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(grande)
+	foto := &model.FotoPerfil{
+		ColaboradorID: colaboradorID,
+		Versao:        hex.EncodeToString(sum[:8]),
+	}
+
+	if foto.CaminhoGrande, err = s.storage.SaveFile(bytes.NewReader(grande), "foto.jpg", "image/jpeg"); err != nil {
+		return "", err
+	}
+	if foto.CaminhoPequeno, err = s.storage.SaveFile(bytes.NewReader(pequena), "foto.jpg", "image/jpeg"); err != nil {
+		s.removerArquivos(&model.FotoPerfil{CaminhoGrande: foto.CaminhoGrande})
+		return "", err
+	}
+
+	url := URLFotoPerfil(colaboradorID, foto.Versao)
+	anterior, err := s.fotoRepo.Save(foto, url)
+	if err != nil {
+		s.removerArquivos(foto)
+		return "", err
+	}
+
+	s.removerArquivos(anterior)
+
+	return url, nil
+}
+
+// Remover apaga a foto do colaborador
+func (s *FotoPerfilService) Remover(colaboradorID int) error {
+	anterior, err := s.fotoRepo.Delete(colaboradorID)
+	if err != nil {
+		return err
+	}
+	if anterior == nil {
+		return ErrFotoNaoEncontrada
+	}
+
+	s.removerArquivos(anterior)
+	return nil
+}
+
+// Obter retorna a foto do colaborador
+func (s *FotoPerfilService) Obter(colaboradorID int) (*model.FotoPerfil, error) {
+	foto, err := s.fotoRepo.Get(colaboradorID)
+	if err != nil {
+		return nil, ErrFotoNaoEncontrada
+	}
+	return foto, nil
+}
+
+// removerArquivos apaga as miniaturas de uma foto que deixou de ser usada.
+// Falhas são apenas registradas no log.
+func (s *FotoPerfilService) removerArquivos(foto *model.FotoPerfil) {
+	if foto == nil {
+		return
+	}
+
+	for _, caminho := range []string{foto.CaminhoGrande, foto.CaminhoPequeno} {
+		if caminho == "" {
+			continue
+		}
+		if err := s.storage.DeleteFile(caminho); err != nil {
+			log.Printf("Erro ao remover foto de perfil %s: %v", caminho, err)
+		}
+	}
+}
+
+// URLFotoPerfil monta a URL da foto; a versão muda a cada envio, o que
+// permite que os clientes guardem a imagem em cache por muito tempo
+func URLFotoPerfil(colaboradorID int, versao string) string {
+	return fmt.Sprintf("/api/fotos/%d?v=%s", colaboradorID, versao)
+}
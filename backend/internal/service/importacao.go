@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/planilha"
+)
+
+// Quantidade máxima de colaboradores por planilha
+const maxLinhasImportacao = 500
+
+var (
+	ErrImportacaoArquivoGrande = errors.New("arquivo muito grande")
+	ErrImportacaoVazia         = errors.New("a planilha não tem colaboradores")
+	ErrImportacaoMuitasLinhas  = fmt.Errorf("a planilha deve ter no máximo %d colaboradores", maxLinhasImportacao)
+	ErrImportacaoComErros      = errors.New("a planilha tem linhas com erro; corrija e envie novamente")
+)
+
+// ImportacaoColunasError indica colunas obrigatórias ausentes no cabeçalho
+type ImportacaoColunasError struct {
+	Faltando []string
+}
+
+func (e *ImportacaoColunasError) Error() string {
+	return "colunas obrigatórias ausentes no cabeçalho: " + strings.Join(e.Faltando, ", ")
+}
+
+// Nomes aceitos no cabeçalho para cada coluna, já normalizados
+var colunasImportacao = map[string][]string{
+	"nome":          {"nome", "nome_completo"},
+	"email":         {"email", "e_mail"},
+	"cargo":         {"cargo", "cargo_id"},
+	"data_admissao": {"data_admissao", "data_de_admissao", "admissao"},
+	"telefone":      {"telefone", "celular"},
+}
+
+var colunasObrigatorias = []string{"nome", "email", "cargo"}
+
+// ImportacaoService cadastra colaboradores em lote a partir de uma planilha.
+// Sem confirmação, apenas valida e devolve o relatório (simulação).
+type ImportacaoService struct {
+	colaboradorRepo *repository.ColaboradorRepository
+	cargoRepo       *repository.CargoRepository
+	convites        *RedefinicaoSenhaService
+	maxFileSize     int64
+}
+
+func NewImportacaoService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	cargoRepo *repository.CargoRepository,
+	convites *RedefinicaoSenhaService,
+	maxFileSize int64,
+) *ImportacaoService {
+	return &ImportacaoService{
+		colaboradorRepo: colaboradorRepo,
+		cargoRepo:       cargoRepo,
+		convites:        convites,
+		maxFileSize:     maxFileSize,
+	}
+}
+
+// Importar valida todas as linhas da planilha. Com confirmar, cadastra os
+// colaboradores em uma única transação, desde que nenhuma linha tenha erro,
+// e envia os convites depois de gravados. O relatório é retornado também
+// junto de ErrImportacaoComErros.
+func (s *ImportacaoService) Importar(arquivo io.Reader, confirmar bool, importadoPor int) (*model.ImportacaoColaboradores, error) {
+	data, err := io.ReadAll(io.LimitReader(arquivo, s.maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return nil, ErrImportacaoArquivoGrande
+	}
+
+	linhas, err := planilha.Ler(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(linhas) < 2 {
+		return nil, ErrImportacaoVazia
+	}
+	if len(linhas)-1 > maxLinhasImportacao {
+		return nil, ErrImportacaoMuitasLinhas
+	}
+
+	indices, err := mapearColunas(linhas[0].Celulas)
+	if err != nil {
+		return nil, err
+	}
+
+	relatorio, err := s.validar(linhas[1:], indices)
+	if err != nil {
+		return nil, err
+	}
+
+	if !confirmar {
+		return relatorio, nil
+	}
+	if relatorio.ComErro > 0 {
+		return relatorio, ErrImportacaoComErros
+	}
+
+	colaboradores := make([]*model.Colaborador, len(relatorio.Linhas))
+	for i, linha := range relatorio.Linhas {
+		// Senha aleatória que ninguém conhece até o colaborador definir a própria
+		senha, err := generateOpaqueToken()
+		if err != nil {
+			return nil, err
+		}
+
+		admissao, _ := time.Parse("2006-01-02", linha.DataAdmissao)
+		colaboradores[i] = &model.Colaborador{
+			Nome:         linha.Nome,
+			Email:        linha.Email,
+			Senha:        senha,
+			CargoID:      linha.CargoID,
+			CargoNome:    linha.CargoNome,
+			DataAdmissao: admissao,
+			Telefone:     linha.Telefone,
+			Status:       StatusAtivo,
+		}
+	}
+
+	if err := s.colaboradorRepo.CreateMany(colaboradores, importadoPor); err != nil {
+		// Email cadastrado por outra pessoa depois da validação
+		var duplicado *repository.EmailDuplicadoLoteError
+		if errors.As(err, &duplicado) {
+			relatorio.Linhas[duplicado.Indice].Erros = append(relatorio.Linhas[duplicado.Indice].Erros, ErrEmailEmUso.Error())
+			relatorio.Validas--
+			relatorio.ComErro++
+			return relatorio, ErrImportacaoComErros
+		}
+		return nil, err
+	}
+
+	relatorio.Confirmada = true
+	for i, colaborador := range colaboradores {
+		relatorio.Linhas[i].ColaboradorID = colaborador.ID
+		colaborador.Senha = ""
+
+		// Os cadastros já existem; o convite pode ser reenviado se o envio falhar
+		if err := s.convites.EnviarConvite(colaborador); err != nil {
+			log.Printf("Erro ao enviar convite ao colaborador %d: %v", colaborador.ID, err)
+		}
+	}
+
+	log.Printf("%d colaboradores cadastrados por importação de planilha", len(colaboradores))
+
+	return relatorio, nil
+}
+
+// validar confere cada linha: campos obrigatórios, formato do email, emails
+// repetidos na planilha ou já cadastrados, cargo existente e data de admissão
+func (s *ImportacaoService) validar(linhas []planilha.Linha, indices map[string]int) (*model.ImportacaoColaboradores, error) {
+	cargos, err := s.cargoRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	cargosPorID := map[int]*model.Cargo{}
+	cargosPorNome := map[string]*model.Cargo{}
+	for _, cargo := range cargos {
+		cargosPorID[cargo.ID] = cargo
+		cargosPorNome[strings.ToLower(strings.TrimSpace(cargo.Nome))] = cargo
+	}
+
+	celula := func(linha []string, coluna string) string {
+		i, ok := indices[coluna]
+		if !ok || i >= len(linha) {
+			return ""
+		}
+		return strings.TrimSpace(linha[i])
+	}
+
+	relatorio := &model.ImportacaoColaboradores{Linhas: make([]*model.ImportacaoColaboradorLinha, 0, len(linhas))}
+	emails := []string{}
+	primeiraLinhaEmail := map[string]int{}
+
+	for _, l := range linhas {
+		linha := l.Celulas
+		resultado := &model.ImportacaoColaboradorLinha{
+			Linha:    l.Numero,
+			Nome:     celula(linha, "nome"),
+			Email:    celula(linha, "email"),
+			Telefone: celula(linha, "telefone"),
+			Erros:    []string{},
+		}
+
+		if resultado.Nome == "" {
+			resultado.Erros = append(resultado.Erros, "nome é obrigatório")
+		}
+
+		if resultado.Email == "" {
+			resultado.Erros = append(resultado.Erros, "email é obrigatório")
+		} else if endereco, err := mail.ParseAddress(resultado.Email); err != nil || endereco.Address != resultado.Email {
+			resultado.Erros = append(resultado.Erros, "email inválido")
+		} else {
+			chave := strings.ToLower(resultado.Email)
+			if anterior, repetido := primeiraLinhaEmail[chave]; repetido {
+				resultado.Erros = append(resultado.Erros, fmt.Sprintf("email repetido na linha %d", anterior))
+			} else {
+				primeiraLinhaEmail[chave] = resultado.Linha
+				emails = append(emails, resultado.Email)
+			}
+		}
+
+		cargo := celula(linha, "cargo")
+		if cargo == "" {
+			resultado.Erros = append(resultado.Erros, "cargo é obrigatório")
+		} else if encontrado := buscarCargo(cargo, cargosPorID, cargosPorNome); encontrado == nil {
+			resultado.Erros = append(resultado.Erros, fmt.Sprintf("cargo %q não encontrado", cargo))
+		} else {
+			resultado.CargoID = encontrado.ID
+			resultado.CargoNome = encontrado.Nome
+		}
+
+		admissao, err := parseDataAdmissao(celula(linha, "data_admissao"))
+		if err != nil {
+			resultado.Erros = append(resultado.Erros, err.Error())
+		} else {
+			resultado.DataAdmissao = admissao.Format("2006-01-02")
+		}
+
+		relatorio.Linhas = append(relatorio.Linhas, resultado)
+	}
+
+	existentes, err := s.colaboradorRepo.ExistingEmails(emails)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resultado := range relatorio.Linhas {
+		if existentes[strings.ToLower(resultado.Email)] {
+			resultado.Erros = append(resultado.Erros, ErrEmailEmUso.Error())
+		}
+
+		if len(resultado.Erros) > 0 {
+			relatorio.ComErro++
+		} else {
+			relatorio.Validas++
+		}
+	}
+	relatorio.Total = len(relatorio.Linhas)
+
+	return relatorio, nil
+}
+
+// buscarCargo aceita o ID ou o nome do cargo (sem diferenciar maiúsculas)
+func buscarCargo(valor string, porID map[int]*model.Cargo, porNome map[string]*model.Cargo) *model.Cargo {
+	if id, err := strconv.Atoi(valor); err == nil {
+		if cargo, ok := porID[id]; ok {
+			return cargo
+		}
+	}
+	return porNome[strings.ToLower(valor)]
+}
+
+// parseDataAdmissao aceita AAAA-MM-DD, DD/MM/AAAA ou uma data do Excel
+// (número de série). Vazia, a admissão é hoje.
+func parseDataAdmissao(valor string) (time.Time, error) {
+	if valor == "" {
+		return time.Now(), nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "02/01/2006", "2/1/2006"} {
+		if data, err := time.Parse(layout, valor); err == nil {
+			return data, nil
+		}
+	}
+
+	// Células de data do XLSX chegam como número de série
+	if serie, err := strconv.ParseFloat(valor, 64); err == nil && serie >= 1 && serie < 100000 {
+		return planilha.DataExcel(serie), nil
+	}
+
+	return time.Time{}, errors.New("data de admissão inválida: use AAAA-MM-DD ou DD/MM/AAAA")
+}
+
+// mapearColunas encontra a posição de cada coluna conhecida pelo cabeçalho.
+// Colunas desconhecidas são ignoradas.
+func mapearColunas(cabecalho []string) (map[string]int, error) {
+	indices := map[string]int{}
+	for i, titulo := range cabecalho {
+		normalizado := normalizarTitulo(titulo)
+		for coluna, nomes := range colunasImportacao {
+			for _, nome := range nomes {
+				if normalizado == nome {
+					if _, ok := indices[coluna]; !ok {
+						indices[coluna] = i
+					}
+				}
+			}
+		}
+	}
+
+	faltando := []string{}
+	for _, coluna := range colunasObrigatorias {
+		if _, ok := indices[coluna]; !ok {
+			faltando = append(faltando, coluna)
+		}
+	}
+	if len(faltando) > 0 {
+		return nil, &ImportacaoColunasError{Faltando: faltando}
+	}
+
+	return indices, nil
+}
+
+// normalizarTitulo deixa o título da coluna em minúsculas, sem acentos e com
+// _ no lugar de espaços e hífens, ex.: "Data de Admissão" vira data_de_admissao
+func normalizarTitulo(titulo string) string {
+	titulo = strings.ToLower(strings.TrimSpace(titulo))
+	titulo = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a",
+		"é", "e", "ê", "e",
+		"í", "i",
+		"ó", "o", "ô", "o", "õ", "o",
+		"ú", "u", "ü", "u",
+		"ç", "c",
+		"-", " ", "_", " ",
+	).Replace(titulo)
+	return strings.Join(strings.Fields(titulo), "_")
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/config"
+	"empresa-app/backend/pkg/oidc"
+)
+
+var (
+	ErrOIDCDesativado          = errors.New("login pelo provedor de identidade não configurado")
+	ErrOIDCAutorizacaoInvalida = errors.New("autorização inválida ou expirada; inicie o login novamente")
+	ErrOIDCProvedor            = errors.New("não foi possível validar o login no provedor de identidade")
+	ErrOIDCEmailNaoVerificado  = errors.New("email não verificado pelo provedor de identidade")
+	ErrOIDCNaoCadastrado       = errors.New("nenhum colaborador cadastrado com este email")
+	ErrOIDCContaAmbigua        = errors.New("mais de um colaborador cadastrado com este email; procure o administrador")
+)
+
+// Tempo que o usuário tem para concluir o login no provedor
+const autorizacaoOIDCExpiration = 10 * time.Minute
+
+// OIDCService faz o login pelo provedor de identidade da empresa (OpenID
+// Connect, authorization code com PKCE). O email do ID token identifica o
+// colaborador e, ao final, são emitidos os tokens da própria aplicação.
+type OIDCService struct {
+	client          *oidc.Client
+	autorizacaoRepo *repository.AutorizacaoOIDCRepository
+	colaboradorRepo *repository.ColaboradorRepository
+	auth            *AuthService
+	config          config.AuthConfig
+}
+
+// NewOIDCService cria o serviço; com client nil o login OIDC fica desativado
+func NewOIDCService(
+	client *oidc.Client,
+	autorizacaoRepo *repository.AutorizacaoOIDCRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	auth *AuthService,
+	cfg config.AuthConfig,
+) *OIDCService {
+	return &OIDCService{
+		client:          client,
+		autorizacaoRepo: autorizacaoRepo,
+		colaboradorRepo: colaboradorRepo,
+		auth:            auth,
+		config:          cfg,
+	}
+}
+
+// Iniciar registra uma nova autorização e retorna a URL do provedor e o state
+func (s *OIDCService) Iniciar(ctx context.Context) (string, string, error) {
+	if s.client == nil {
+		return "", "", ErrOIDCDesativado
+	}
+
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := s.client.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		log.Printf("Erro ao montar URL de login OIDC: %v", err)
+		return "", "", ErrOIDCProvedor
+	}
+
+	now := time.Now()
+	if err := s.autorizacaoRepo.DeleteExpired(now); err != nil {
+		log.Printf("Erro ao remover autorizações OIDC expiradas: %v", err)
+	}
+
+	autorizacao := &model.AutorizacaoOIDC{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiraEm:     now.Add(autorizacaoOIDCExpiration),
+	}
+	if err := s.autorizacaoRepo.Create(autorizacao); err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// Concluir troca o código retornado pelo provedor, valida o ID token e faz o
+// login do colaborador com o email informado pelo provedor
+func (s *OIDCService) Concluir(ctx context.Context, state, code, ip, userAgent string) (*model.LoginResponse, error) {
+	if s.client == nil {
+		return nil, ErrOIDCDesativado
+	}
+
+	autorizacao, err := s.autorizacaoRepo.Consume(state, time.Now())
+	if err != nil {
+		return nil, ErrOIDCAutorizacaoInvalida
+	}
+
+	rawIDToken, err := s.client.Exchange(ctx, code, autorizacao.CodeVerifier)
+	if err != nil {
+		log.Printf("Erro na troca do código OIDC: %v", err)
+		return nil, ErrOIDCProvedor
+	}
+
+	claims, err := s.client.VerifyIDToken(ctx, rawIDToken, autorizacao.Nonce)
+	if err != nil {
+		log.Printf("ID token OIDC rejeitado: %v", err)
+		return nil, ErrOIDCProvedor
+	}
+
+	// Sem a claim email_verified, o email não é considerado verificado
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || claims.EmailVerified == nil || !*claims.EmailVerified {
+		return nil, ErrOIDCEmailNaoVerificado
+	}
+
+	// Provedores podem devolver o email com outra capitalização
+	colaborador, err := s.colaboradorRepo.GetByEmailIgnoreCase(email)
+	switch {
+	case errors.Is(err, repository.ErrEmailAmbiguo):
+		return nil, ErrOIDCContaAmbigua
+	case errors.Is(err, repository.ErrColaboradorNaoEncontrado):
+		if !s.config.OIDCAutoProvisionar {
+			return nil, ErrOIDCNaoCadastrado
+		}
+
+		colaborador, err = s.provisionar(email, claims.Name)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	return s.auth.LoginExterno(colaborador, ip, userAgent)
+}
+
+// provisionar cria o colaborador no primeiro login, com o cargo padrão e uma
+// senha aleatória que ninguém conhece (pode ser redefinida pelo "esqueci a senha")
+func (s *OIDCService) provisionar(email, nome string) (*model.Colaborador, error) {
+	senha, err := oidc.RandomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	nome = strings.TrimSpace(nome)
+	if nome == "" {
+		nome = email
+	}
+
+	colaborador := &model.Colaborador{
+		Nome:         nome,
+		Email:        email,
+		Senha:        senha,
+		CargoID:      s.config.OIDCCargoPadrao,
+		DataAdmissao: time.Now(),
+		Status:       StatusAtivo,
+	}
+
+	if err := s.colaboradorRepo.Create(colaborador, 0); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Colaborador %d criado no primeiro login pelo provedor de identidade", colaborador.ID)
+
+	return colaborador, nil
+}
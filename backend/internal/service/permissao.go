@@ -0,0 +1,142 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// Permissões conhecidas pelo sistema
+const (
+	PermDocumentosVerTodos        = "documentos.ver_todos"
+	PermDocumentosAprovar         = "documentos.aprovar"
+	PermDocumentosEnviar          = "documentos.enviar"
+	PermPontoVerEquipe            = "ponto.ver_equipe"
+	PermSessoesRevogar            = "sessoes.revogar"
+	PermPermissoesGerenciar       = "permissoes.gerenciar"
+	PermContasDesbloquear         = "contas.desbloquear"
+	PermContasServicoGerenciar    = "contas_servico.gerenciar"
+	PermColaboradoresGerenciar    = "colaboradores.gerenciar"
+	PermColaboradoresPersonificar = "colaboradores.personificar"
+	PermCargosGerenciar           = "cargos.gerenciar"
+	PermDadosBancariosRevelar     = "dados_bancarios.revelar"
+	PermDepartamentosGerenciar    = "departamentos.gerenciar"
+	PermColaboradoresAnonimizar   = "colaboradores.anonimizar"
+)
+
+// permissoesCacheTTL define de quanto em quanto tempo as concessões são
+// recarregadas do banco, refletindo alterações feitas por outras instâncias
+const permissoesCacheTTL = time.Minute
+
+type PermissaoService struct {
+	permissaoRepo *repository.PermissaoRepository
+	cargoRepo     *repository.CargoRepository
+
+	mu          sync.RWMutex
+	grants      map[int]map[string]bool
+	carregadoEm time.Time
+}
+
+func NewPermissaoService(permissaoRepo *repository.PermissaoRepository, cargoRepo *repository.CargoRepository) *PermissaoService {
+	return &PermissaoService{
+		permissaoRepo: permissaoRepo,
+		cargoRepo:     cargoRepo,
+		grants:        map[int]map[string]bool{},
+	}
+}
+
+// HasPermission verifica se o cargo possui a permissão
+func (s *PermissaoService) HasPermission(cargoID int, permissao string) bool {
+	s.mu.RLock()
+	expirado := time.Since(s.carregadoEm) > permissoesCacheTTL
+	s.mu.RUnlock()
+
+	if expirado {
+		if err := s.Load(); err != nil {
+			// Em caso de falha, segue com o cache anterior
+			log.Printf("Erro ao recarregar permissões: %v", err)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grants[cargoID][permissao]
+}
+
+// Load recarrega todas as concessões do banco
+func (s *PermissaoService) Load() error {
+	grants, err := s.permissaoRepo.ListGrants()
+	if err != nil {
+		return err
+	}
+
+	cache := make(map[int]map[string]bool, len(grants))
+	for cargoID, codigos := range grants {
+		cache[cargoID] = make(map[string]bool, len(codigos))
+		for _, codigo := range codigos {
+			cache[cargoID][codigo] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.grants = cache
+	s.carregadoEm = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *PermissaoService) List() ([]*model.Permissao, error) {
+	return s.permissaoRepo.List()
+}
+
+// GetCargo retorna o cargo com as permissões concedidas
+func (s *PermissaoService) GetCargo(cargoID int) (*model.Cargo, error) {
+	cargo, err := s.cargoRepo.GetByID(cargoID)
+	if err != nil {
+		return nil, err
+	}
+
+	cargo.Permissoes, err = s.permissaoRepo.ListByCargo(cargoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cargo, nil
+}
+
+// Grant concede a permissão ao cargo
+func (s *PermissaoService) Grant(cargoID int, permissao string, concedidoPor int) error {
+	if _, err := s.cargoRepo.GetByID(cargoID); err != nil {
+		return err
+	}
+
+	if err := s.permissaoRepo.Grant(cargoID, permissao, concedidoPor); err != nil {
+		return err
+	}
+
+	dados := map[string]interface{}{"permissao": permissao}
+	if err := s.cargoRepo.RegistrarHistorico(cargoID, repository.AcaoCargoPermissaoConcedida, concedidoPor, nil, dados); err != nil {
+		return err
+	}
+
+	return s.Load()
+}
+
+// Revoke remove a permissão do cargo
+func (s *PermissaoService) Revoke(cargoID int, permissao string, removidoPor int) error {
+	if err := s.permissaoRepo.Revoke(cargoID, permissao); err != nil {
+		return err
+	}
+
+	dados := map[string]interface{}{"permissao": permissao}
+	if err := s.cargoRepo.RegistrarHistorico(cargoID, repository.AcaoCargoPermissaoRemovida, removidoPor, dados, nil); err != nil {
+		return err
+	}
+
+	return s.Load()
+}
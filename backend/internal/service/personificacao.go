@@ -0,0 +1,158 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// ErrAtorSemAcesso indica que o administrador que iniciou a personificação
+// foi desligado, afastado ou perdeu a permissão de personificar
+var ErrAtorSemAcesso = errors.New("personificação encerrada: o administrador não tem mais acesso")
+
+type PersonificacaoService struct {
+	personificacaoRepo *repository.PersonificacaoRepository
+	colaboradorRepo    *repository.ColaboradorRepository
+	authService        *AuthService
+	permissoes         *PermissaoService
+	revogacao          *RevogacaoService
+	duracao            time.Duration
+}
+
+func NewPersonificacaoService(
+	personificacaoRepo *repository.PersonificacaoRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	authService *AuthService,
+	permissoes *PermissaoService,
+	revogacao *RevogacaoService,
+	duracao time.Duration,
+) *PersonificacaoService {
+	return &PersonificacaoService{
+		personificacaoRepo: personificacaoRepo,
+		colaboradorRepo:    colaboradorRepo,
+		authService:        authService,
+		permissoes:         permissoes,
+		revogacao:          revogacao,
+		duracao:            duracao,
+	}
+}
+
+// Iniciar registra a personificação e emite o token de acesso do alvo
+func (s *PersonificacaoService) Iniciar(atorID, alvoID int, motivo, ip string) (*model.PersonificacaoResponse, error) {
+	if atorID == alvoID {
+		return nil, errors.New("não é possível personificar a si mesmo")
+	}
+
+	alvo, err := s.colaboradorRepo.GetByID(alvoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if AcessoDoStatus(alvo.Status) == AcessoNegado {
+		return nil, ErrColaboradorSemAcesso
+	}
+
+	personificacao := &model.Personificacao{
+		AtorID:   atorID,
+		AlvoID:   alvoID,
+		Motivo:   motivo,
+		JTI:      uuid.New().String(),
+		IP:       ip,
+		ExpiraEm: time.Now().Add(s.duracao),
+	}
+	if err := s.personificacaoRepo.Create(personificacao); err != nil {
+		return nil, err
+	}
+
+	token, err := s.authService.GenerateImpersonationToken(personificacao, alvo.CargoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PersonificacaoResponse{
+		Token:          token,
+		ExpiresIn:      int(s.duracao.Seconds()),
+		Personificacao: personificacao,
+	}, nil
+}
+
+// Encerrar revoga o token da personificação antes do prazo
+func (s *PersonificacaoService) Encerrar(id int) error {
+	personificacao, err := s.personificacaoRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.personificacaoRepo.End(id); err != nil {
+		return err
+	}
+
+	return s.revogacao.RevogarToken(personificacao.JTI, personificacao.AlvoID, personificacao.ExpiraEm)
+}
+
+// VerificarAtor confere, a cada requisição personificada, se o administrador
+// ainda tem acesso total e a permissão de personificar com o cargo atual
+func (s *PersonificacaoService) VerificarAtor(atorID int) error {
+	ator, err := s.colaboradorRepo.GetByID(atorID)
+	if err != nil {
+		return err
+	}
+
+	if AcessoDoStatus(ator.Status) != AcessoTotal ||
+		!s.permissoes.HasPermission(ator.CargoID, PermColaboradoresPersonificar) {
+		return ErrAtorSemAcesso
+	}
+
+	return nil
+}
+
+// EncerrarDoAtor encerra e revoga as personificações em andamento iniciadas
+// pelo administrador
+func (s *PersonificacaoService) EncerrarDoAtor(atorID int) error {
+	personificacoes, err := s.personificacaoRepo.EndByAtor(atorID)
+	if err != nil {
+		return err
+	}
+
+	for _, personificacao := range personificacoes {
+		if err := s.revogacao.RevogarToken(personificacao.JTI, personificacao.AlvoID, personificacao.ExpiraEm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PersonificacaoService) List(limit, offset int) ([]*model.Personificacao, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.personificacaoRepo.List(limit, offset)
+}
+
+func (s *PersonificacaoService) ListRequisicoes(id int) ([]*model.RequisicaoPersonificacao, error) {
+	return s.personificacaoRepo.ListRequisicoes(id)
+}
+
+// RegistrarRequisicao grava uma requisição feita com o token de personificação
+// antes de executá-la; se a gravação falhar, a requisição não deve prosseguir
+func (s *PersonificacaoService) RegistrarRequisicao(requisicao *model.RequisicaoPersonificacao) error {
+	return s.personificacaoRepo.CreateRequisicao(requisicao)
+}
+
+// ConcluirRequisicao grava o status HTTP da resposta. Falhas são apenas
+// registradas no log, pois a requisição já foi executada.
+func (s *PersonificacaoService) ConcluirRequisicao(id, status int) {
+	if err := s.personificacaoRepo.UpdateRequisicaoStatus(id, status); err != nil {
+		log.Printf("Erro ao registrar status da requisição %d da personificação: %v", id, err)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/config"
+	"empresa-app/backend/pkg/passhash"
+)
+
+// PoliticaSenhaError indica que a nova senha não atende à política; a
+// mensagem pode ser exibida ao usuário
+type PoliticaSenhaError struct {
+	Motivo string
+}
+
+func (e *PoliticaSenhaError) Error() string {
+	return e.Motivo
+}
+
+// PoliticaSenhaService valida novas senhas: tamanho mínimo, lista local de
+// senhas vazadas e reutilização das últimas senhas do colaborador
+type PoliticaSenhaService struct {
+	historicoRepo *repository.HistoricoSenhaRepository
+	hasher        *passhash.Hasher
+	config        config.AuthConfig
+	vazadas       map[string]struct{}
+}
+
+func NewPoliticaSenhaService(historicoRepo *repository.HistoricoSenhaRepository, hasher *passhash.Hasher, cfg config.AuthConfig) *PoliticaSenhaService {
+	return &PoliticaSenhaService{
+		historicoRepo: historicoRepo,
+		hasher:        hasher,
+		config:        cfg,
+		vazadas:       map[string]struct{}{},
+	}
+}
+
+// Load carrega a lista de senhas vazadas do arquivo configurado, se houver
+func (s *PoliticaSenhaService) Load() error {
+	if s.config.SenhaListaVazadas == "" {
+		return nil
+	}
+
+	file, err := os.Open(s.config.SenhaListaVazadas)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	vazadas := map[string]struct{}{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		senha := strings.TrimSpace(scanner.Text())
+		if senha == "" || strings.HasPrefix(senha, "#") {
+			continue
+		}
+		vazadas[strings.ToLower(senha)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	s.vazadas = vazadas
+	log.Printf("Lista de senhas vazadas carregada: %d senhas", len(vazadas))
+
+	return nil
+}
+
+// Validar verifica a nova senha contra a política. senhaAtualHash é o hash
+// da senha em uso, que também não pode ser repetida.
+func (s *PoliticaSenhaService) Validar(colaboradorID int, senhaAtualHash, novaSenha string) error {
+	if utf8.RuneCountInString(novaSenha) < s.config.SenhaTamanhoMinimo {
+		return &PoliticaSenhaError{Motivo: fmt.Sprintf("nova senha deve ter pelo menos %d caracteres", s.config.SenhaTamanhoMinimo)}
+	}
+
+	if _, ok := s.vazadas[strings.ToLower(novaSenha)]; ok {
+		return &PoliticaSenhaError{Motivo: "esta senha aparece em vazamentos conhecidos; escolha outra"}
+	}
+
+	hashes := []string{senhaAtualHash}
+	if s.config.SenhaHistorico > 0 {
+		anteriores, err := s.historicoRepo.ListRecent(colaboradorID, s.config.SenhaHistorico)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, anteriores...)
+	}
+
+	for _, hash := range hashes {
+		if hash != "" && s.hasher.Verify(hash, novaSenha) {
+			return &PoliticaSenhaError{Motivo: "nova senha não pode ser igual às senhas usadas recentemente"}
+		}
+	}
+
+	return nil
+}
+
+// Registrar guarda o hash da senha substituída no histórico
+func (s *PoliticaSenhaService) Registrar(colaboradorID int, senhaAnteriorHash string) error {
+	if s.config.SenhaHistorico <= 0 {
+		return nil
+	}
+
+	return s.historicoRepo.Create(colaboradorID, senhaAnteriorHash, s.config.SenhaHistorico)
+}
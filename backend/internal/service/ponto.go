@@ -38,13 +38,13 @@ func (s *PontoService) Registrar(ponto *model.RegistroPonto) error {
 	return s.pontoRepo.Registrar(ponto)
 }
 
-func (s *PontoService) Listar(colaboradorID int, data time.Time) ([]*model.RegistroPonto, error) {
+func (s *PontoService) Listar(filtro model.PontoFiltro) ([]*model.RegistroPonto, error) {
 	// Se data não for fornecida, usar data atual
-	if data.IsZero() {
-		data = time.Now()
+	if filtro.Data.IsZero() {
+		filtro.Data = time.Now()
 	}
 
-	return s.pontoRepo.Listar(colaboradorID, data)
+	return s.pontoRepo.Listar(filtro)
 }
 
 func (s *PontoService) ParseLocalizacao(localizacaoJSON string) ([]byte, error) {
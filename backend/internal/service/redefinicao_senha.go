@@ -0,0 +1,202 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/url"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/mailer"
+)
+
+var ErrTokenRedefinicaoInvalido = errors.New("token de redefinição inválido ou expirado")
+
+type RedefinicaoSenhaService struct {
+	colaboradorRepo *repository.ColaboradorRepository
+	tokenRepo       *repository.TokenRedefinicaoSenhaRepository
+	politica        *PoliticaSenhaService
+	authService     *AuthService
+	tentativas      *TentativaLoginService
+	mailer          mailer.Mailer
+	appURL          string
+	validade        time.Duration
+	validadeConvite time.Duration
+}
+
+func NewRedefinicaoSenhaService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	tokenRepo *repository.TokenRedefinicaoSenhaRepository,
+	politica *PoliticaSenhaService,
+	authService *AuthService,
+	tentativas *TentativaLoginService,
+	m mailer.Mailer,
+	appURL string,
+	validade time.Duration,
+	validadeConvite time.Duration,
+) *RedefinicaoSenhaService {
+	return &RedefinicaoSenhaService{
+		colaboradorRepo: colaboradorRepo,
+		tokenRepo:       tokenRepo,
+		politica:        politica,
+		authService:     authService,
+		tentativas:      tentativas,
+		mailer:          m,
+		appURL:          appURL,
+		validade:        validade,
+		validadeConvite: validadeConvite,
+	}
+}
+
+// Solicitar gera um token de redefinição e o envia por email. Não informa se
+// o email existe, para não permitir enumeração de contas. Os pedidos são
+// limitados por email e por IP (ver TentativaLoginService.LimitarRedefinicao).
+func (s *RedefinicaoSenhaService) Solicitar(email, ip string) error {
+	if err := s.tentativas.LimitarRedefinicao(email, ip); err != nil {
+		return err
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	token, err := s.emitirToken(colaborador.ID, s.validade)
+	if err != nil {
+		return err
+	}
+
+	html, err := mailer.Render("redefinicao_senha.html", map[string]interface{}{
+		"Nome":            colaborador.Nome,
+		"Link":            s.link(token),
+		"ValidadeMinutos": int(s.validade.Minutes()),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Envio assíncrono: o tempo de resposta não deve revelar se a conta existe
+	s.enviar(colaborador.Email, "Redefinição de senha", html)
+
+	return nil
+}
+
+// EnviarConvite envia ao colaborador recém-cadastrado o link para definir a
+// própria senha. O link usa o mesmo fluxo da redefinição, com validade maior.
+func (s *RedefinicaoSenhaService) EnviarConvite(colaborador *model.Colaborador) error {
+	token, err := s.emitirToken(colaborador.ID, s.validadeConvite)
+	if err != nil {
+		return err
+	}
+
+	html, err := mailer.Render("convite.html", map[string]interface{}{
+		"Nome":          colaborador.Nome,
+		"Email":         colaborador.Email,
+		"Link":          s.link(token),
+		"ValidadeHoras": int(s.validadeConvite.Hours()),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.enviar(colaborador.Email, "Bem-vindo(a) ao aplicativo RLS Automação Industrial", html)
+
+	return nil
+}
+
+// emitirToken gera e grava um token de uso único para definir a senha
+func (s *RedefinicaoSenhaService) emitirToken(colaboradorID int, validade time.Duration) (string, error) {
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	stored := &model.TokenRedefinicaoSenha{
+		ColaboradorID: colaboradorID,
+		TokenHash:     hashToken(token),
+		ExpiraEm:      time.Now().Add(validade),
+	}
+	if err := s.tokenRepo.Create(stored); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *RedefinicaoSenhaService) link(token string) string {
+	return s.appURL + "/redefinir-senha?token=" + url.QueryEscape(token)
+}
+
+// enviar envia o email em segundo plano; falhas são apenas registradas no log
+func (s *RedefinicaoSenhaService) enviar(para, assunto, html string) {
+	go func() {
+		err := s.mailer.Send(mailer.Message{
+			To:      para,
+			Subject: assunto,
+			HTML:    html,
+		})
+		if err != nil {
+			log.Printf("Erro ao enviar email (%s): %v", assunto, err)
+		}
+	}()
+}
+
+// Redefinir troca a senha usando o token recebido por email e encerra todas as
+// sessões do colaborador
+func (s *RedefinicaoSenhaService) Redefinir(token, novaSenha string) error {
+	stored, err := s.tokenRepo.GetByHash(hashToken(token))
+	if err != nil {
+		return ErrTokenRedefinicaoInvalido
+	}
+
+	if stored.UsadoEm != nil || time.Now().After(stored.ExpiraEm) {
+		return ErrTokenRedefinicaoInvalido
+	}
+
+	colaborador, err := s.colaboradorRepo.GetByIDWithPassword(stored.ColaboradorID)
+	if err != nil {
+		return err
+	}
+
+	// Validar antes de consumir o token, para que o usuário possa tentar outra senha
+	if err := s.politica.Validar(colaborador.ID, colaborador.Senha, novaSenha); err != nil {
+		return err
+	}
+
+	consumed, err := s.tokenRepo.MarkAsUsed(stored.ID)
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrTokenRedefinicaoInvalido
+	}
+
+	hashedSenha, err := s.colaboradorRepo.HashPassword(novaSenha)
+	if err != nil {
+		return err
+	}
+
+	if err := s.colaboradorRepo.UpdatePassword(stored.ColaboradorID, hashedSenha); err != nil {
+		return err
+	}
+
+	if err := s.politica.Registrar(stored.ColaboradorID, colaborador.Senha); err != nil {
+		return err
+	}
+
+	// Outros links enviados deixam de valer
+	if err := s.tokenRepo.InvalidateByColaborador(stored.ColaboradorID); err != nil {
+		return err
+	}
+
+	return s.authService.RevogarTodos(stored.ColaboradorID)
+}
+
+// hashToken calcula o SHA-256 de um token aleatório de alta entropia
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+)
+
+// RevogacaoService mantém a lista de tokens revogados. O Postgres é a fonte
+// da verdade; um cache em memória evita uma consulta por requisição e é
+// recarregado periodicamente para refletir revogações feitas por outras instâncias.
+type RevogacaoService struct {
+	tokenRevogadoRepo *repository.TokenRevogadoRepository
+
+	mu            sync.RWMutex
+	jtis          map[string]time.Time // jti -> expiração do token
+	colaboradores map[int]time.Time    // colaborador -> tokens emitidos até este instante são inválidos
+	sessoes       map[int]time.Time    // sessão encerrada -> expiração da entrada
+}
+
+func NewRevogacaoService(tokenRevogadoRepo *repository.TokenRevogadoRepository) *RevogacaoService {
+	return &RevogacaoService{
+		tokenRevogadoRepo: tokenRevogadoRepo,
+		jtis:              map[string]time.Time{},
+		colaboradores:     map[int]time.Time{},
+		sessoes:           map[int]time.Time{},
+	}
+}
+
+// RevogarToken revoga um token de acesso individual
+func (s *RevogacaoService) RevogarToken(jti string, colaboradorID int, expiraEm time.Time) error {
+	err := s.tokenRevogadoRepo.Revoke(&model.TokenRevogado{
+		JTI:           jti,
+		ColaboradorID: colaboradorID,
+		ExpiraEm:      expiraEm,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.jtis[jti] = expiraEm
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ConsumirToken revoga um token de uso único, informando se ele ainda não
+// havia sido usado
+func (s *RevogacaoService) ConsumirToken(jti string, colaboradorID int, expiraEm time.Time) (bool, error) {
+	consumido, err := s.tokenRevogadoRepo.Consume(&model.TokenRevogado{
+		JTI:           jti,
+		ColaboradorID: colaboradorID,
+		ExpiraEm:      expiraEm,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.jtis[jti] = expiraEm
+	s.mu.Unlock()
+
+	return consumido, nil
+}
+
+// RevogarColaborador revoga todos os tokens do colaborador emitidos até agora.
+// tokenTTL é a duração máxima de um token de acesso, após a qual a entrada
+// deixa de ser necessária.
+func (s *RevogacaoService) RevogarColaborador(colaboradorID int, tokenTTL time.Duration) error {
+	now := time.Now()
+	if err := s.tokenRevogadoRepo.RevokeAllByColaborador(colaboradorID, now, now.Add(tokenTTL)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.colaboradores[colaboradorID] = now
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RevogarSessao revoga todos os tokens de acesso emitidos para a sessão
+func (s *RevogacaoService) RevogarSessao(sessaoID int, tokenTTL time.Duration) error {
+	expiraEm := time.Now().Add(tokenTTL)
+	if err := s.tokenRevogadoRepo.RevokeSessao(sessaoID, expiraEm); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.sessoes[sessaoID] = expiraEm
+	s.mu.Unlock()
+
+	return nil
+}
+
+// IsRevogado informa se o token identificado por jti, emitido em emitidoEm
+// para o colaborador na sessão informada, foi revogado
+func (s *RevogacaoService) IsRevogado(jti string, colaboradorID, sessaoID int, emitidoEm time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.jtis[jti]; ok {
+		return true
+	}
+
+	if _, ok := s.sessoes[sessaoID]; ok {
+		return true
+	}
+
+	if revogadoEm, ok := s.colaboradores[colaboradorID]; ok && !emitidoEm.After(revogadoEm) {
+		return true
+	}
+
+	return false
+}
+
+// Load carrega as revogações ativas do banco para o cache
+func (s *RevogacaoService) Load() error {
+	now := time.Now()
+
+	tokens, err := s.tokenRevogadoRepo.ListActive(now)
+	if err != nil {
+		return err
+	}
+
+	colaboradores, err := s.tokenRevogadoRepo.ListActiveColaboradores(now)
+	if err != nil {
+		return err
+	}
+
+	sessoes, err := s.tokenRevogadoRepo.ListActiveSessoes(now)
+	if err != nil {
+		return err
+	}
+
+	jtis := make(map[string]time.Time, len(tokens))
+	for _, token := range tokens {
+		jtis[token.JTI] = token.ExpiraEm
+	}
+
+	s.mu.Lock()
+	s.jtis = jtis
+	s.colaboradores = colaboradores
+	s.sessoes = sessoes
+	s.mu.Unlock()
+
+	return nil
+}
+
+// StartCleanup remove periodicamente as entradas expiradas e recarrega o cache
+func (s *RevogacaoService) StartCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			if err := s.tokenRevogadoRepo.DeleteExpired(time.Now()); err != nil {
+				log.Printf("Erro ao limpar tokens revogados expirados: %v", err)
+			}
+			if err := s.Load(); err != nil {
+				log.Printf("Erro ao recarregar tokens revogados: %v", err)
+			}
+		}
+	}()
+}
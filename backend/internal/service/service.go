@@ -1,8 +1,29 @@
 package service
 
 type Services struct {
-	Auth        *AuthService
-	Colaborador *ColaboradorService
-	Documento   *DocumentoService
-	Ponto       *PontoService
+	Auth            *AuthService
+	Colaborador     *ColaboradorService
+	Documento       *DocumentoService
+	Ponto           *PontoService
+	Revogacao       *RevogacaoService
+	Permissao       *PermissaoService
+	Redefinicao     *RedefinicaoSenhaService
+	DoisFatores     *DoisFatoresService
+	Tentativa       *TentativaLoginService
+	ContaServico    *ContaServicoService
+	Sessao          *SessaoService
+	PoliticaSenha   *PoliticaSenhaService
+	Status          *StatusColaboradorService
+	Personificacao  *PersonificacaoService
+	OIDC            *OIDCService
+	Cargo           *CargoService
+	FotoPerfil      *FotoPerfilService
+	DadosBancarios  *DadosBancariosService
+	Importacao      *ImportacaoService
+	Departamento    *DepartamentoService
+	CentroCusto     *CentroCustoService
+	Alocacao        *AlocacaoService
+	Desligamento    *DesligamentoService
+	ExportacaoDados *ExportacaoDadosService
+	Anonimizacao    *AnonimizacaoService
 }
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/config"
+)
+
+var ErrSessaoNaoEncontrada = errors.New("sessão não encontrada")
+
+// Intervalo mínimo entre duas gravações do último acesso de uma sessão
+const intervaloRegistroAcesso = time.Minute
+
+// SessaoService controla as sessões (uma por login) e o encerramento remoto
+// de dispositivos. Encerrar uma sessão revoga sua família de refresh tokens e
+// os tokens de acesso já emitidos para ela.
+type SessaoService struct {
+	sessaoRepo       *repository.SessaoRepository
+	refreshTokenRepo *repository.RefreshTokenRepository
+	revogacao        *RevogacaoService
+	config           config.AuthConfig
+
+	mu       sync.Mutex
+	acessoEm map[int]time.Time // sessão -> último acesso gravado
+}
+
+func NewSessaoService(
+	sessaoRepo *repository.SessaoRepository,
+	refreshTokenRepo *repository.RefreshTokenRepository,
+	revogacao *RevogacaoService,
+	cfg config.AuthConfig,
+) *SessaoService {
+	return &SessaoService{
+		sessaoRepo:       sessaoRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revogacao:        revogacao,
+		config:           cfg,
+		acessoEm:         map[int]time.Time{},
+	}
+}
+
+// Registrar abre a sessão da família no login ou renova sua validade no
+// refresh. Retorna erro se a sessão já tiver sido encerrada.
+func (s *SessaoService) Registrar(colaboradorID int, familia, ip, userAgent string) (*model.Sessao, error) {
+	sessao := &model.Sessao{
+		ColaboradorID: colaboradorID,
+		Familia:       familia,
+		Dispositivo:   DescreverDispositivo(userAgent),
+		UserAgent:     userAgent,
+		IP:            ip,
+		ExpiraEm:      time.Now().Add(time.Duration(s.config.RefreshExpiration) * time.Hour),
+	}
+
+	if err := s.sessaoRepo.Upsert(sessao); err != nil {
+		return nil, err
+	}
+
+	return sessao, nil
+}
+
+// Listar retorna as sessões ativas do colaborador, marcando a sessão atual
+func (s *SessaoService) Listar(colaboradorID, sessaoAtual int) ([]*model.Sessao, error) {
+	sessoes, err := s.sessaoRepo.ListActive(colaboradorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sessao := range sessoes {
+		sessao.Atual = sessao.ID == sessaoAtual
+	}
+
+	return sessoes, nil
+}
+
+// Encerrar encerra uma sessão do colaborador
+func (s *SessaoService) Encerrar(colaboradorID, sessaoID int) error {
+	familia, err := s.sessaoRepo.End(sessaoID, colaboradorID)
+	if errors.Is(err, repository.ErrSessaoNaoEncontrada) {
+		return ErrSessaoNaoEncontrada
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(familia); err != nil {
+		return err
+	}
+
+	return s.revogacao.RevogarSessao(sessaoID, s.tokenTTL())
+}
+
+// EncerrarOutras encerra todas as sessões do colaborador exceto a atual
+func (s *SessaoService) EncerrarOutras(colaboradorID, sessaoAtual int) error {
+	sessoes, err := s.sessaoRepo.ListActive(colaboradorID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, sessao := range sessoes {
+		if sessao.ID == sessaoAtual {
+			continue
+		}
+		if err := s.Encerrar(colaboradorID, sessao.ID); err != nil && !errors.Is(err, ErrSessaoNaoEncontrada) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncerrarFamilia encerra a sessão ligada à família de refresh tokens
+func (s *SessaoService) EncerrarFamilia(familia string) error {
+	if err := s.refreshTokenRepo.RevokeFamily(familia); err != nil {
+		return err
+	}
+
+	sessaoID, err := s.sessaoRepo.EndByFamilia(familia)
+	if err != nil {
+		return err
+	}
+	if sessaoID == 0 {
+		return nil
+	}
+
+	return s.revogacao.RevogarSessao(sessaoID, s.tokenTTL())
+}
+
+// EncerrarTodas encerra todas as sessões do colaborador, invalidando todos os
+// seus tokens de acesso e refresh tokens
+func (s *SessaoService) EncerrarTodas(colaboradorID int) error {
+	if err := s.refreshTokenRepo.RevokeByColaborador(colaboradorID); err != nil {
+		return err
+	}
+
+	if err := s.sessaoRepo.EndAllByColaborador(colaboradorID); err != nil {
+		return err
+	}
+
+	return s.revogacao.RevogarColaborador(colaboradorID, s.tokenTTL())
+}
+
+// RegistrarAcesso atualiza o último acesso da sessão, gravando no máximo uma
+// vez por intervaloRegistroAcesso. Falhas são apenas registradas no log.
+func (s *SessaoService) RegistrarAcesso(sessaoID int, ip string) {
+	if sessaoID == 0 {
+		return
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	if now.Sub(s.acessoEm[sessaoID]) < intervaloRegistroAcesso {
+		s.mu.Unlock()
+		return
+	}
+	s.acessoEm[sessaoID] = now
+
+	// Descartar entradas antigas para o mapa não crescer indefinidamente
+	if len(s.acessoEm) > 10000 {
+		for id, em := range s.acessoEm {
+			if now.Sub(em) >= intervaloRegistroAcesso {
+				delete(s.acessoEm, id)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.sessaoRepo.Touch(sessaoID, ip); err != nil {
+		log.Printf("Erro ao registrar acesso da sessão %d: %v", sessaoID, err)
+	}
+}
+
+func (s *SessaoService) tokenTTL() time.Duration {
+	return time.Duration(s.config.TokenExpiration) * time.Minute
+}
+
+// DescreverDispositivo gera um nome legível para o dispositivo a partir do
+// User-Agent (o mesmo registrado nas batidas de ponto)
+func DescreverDispositivo(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	var sistema string
+	switch {
+	case strings.Contains(ua, "iphone"):
+		sistema = "iPhone"
+	case strings.Contains(ua, "ipad"):
+		sistema = "iPad"
+	case strings.Contains(ua, "android"):
+		sistema = "Android"
+	case strings.Contains(ua, "okhttp"):
+		// Cliente HTTP padrão do React Native no Android
+		return "Aplicativo Android"
+	case strings.Contains(ua, "cfnetwork") || strings.Contains(ua, "darwin"):
+		// Cliente HTTP padrão do React Native no iOS
+		return "Aplicativo iOS"
+	case strings.Contains(ua, "windows"):
+		sistema = "Windows"
+	case strings.Contains(ua, "mac os"):
+		sistema = "macOS"
+	case strings.Contains(ua, "linux"):
+		sistema = "Linux"
+	default:
+		return "Dispositivo desconhecido"
+	}
+
+	var navegador string
+	switch {
+	case strings.Contains(ua, "edg/"):
+		navegador = "Edge"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		navegador = "Chrome"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		navegador = "Firefox"
+	case strings.Contains(ua, "safari/"):
+		navegador = "Safari"
+	}
+
+	if navegador == "" {
+		return sistema
+	}
+	return navegador + " em " + sistema
+}
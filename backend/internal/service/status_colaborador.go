@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"empresa-app/backend/internal/repository"
+)
+
+// Status possíveis de um colaborador
+const (
+	StatusAtivo     = "ativo"
+	StatusFerias    = "ferias"
+	StatusAfastado  = "afastado"
+	StatusDesligado = "desligado"
+)
+
+// Acesso define o que um colaborador pode fazer de acordo com o status
+type Acesso int
+
+const (
+	// AcessoNegado impede o login e invalida os tokens existentes
+	AcessoNegado Acesso = iota
+	// AcessoSomenteLeitura permite consultas e apenas as ações de
+	// autoatendimento liberadas no middleware (logout, troca de senha, envio
+	// de documentos como atestados e recibos)
+	AcessoSomenteLeitura
+	// AcessoTotal não impõe restrições além das permissões do cargo
+	AcessoTotal
+)
+
+var regrasStatus = map[string]Acesso{
+	StatusAtivo:     AcessoTotal,
+	StatusFerias:    AcessoSomenteLeitura,
+	StatusAfastado:  AcessoSomenteLeitura,
+	StatusDesligado: AcessoNegado,
+}
+
+var (
+	ErrColaboradorSemAcesso   = errors.New("acesso bloqueado: colaborador desligado")
+	ErrStatusInvalido         = errors.New("status inválido: use ativo, ferias, afastado ou desligado")
+	ErrColaboradorAnonimizado = errors.New("colaborador anonimizado (LGPD) não pode ser reativado")
+	ErrDesligamentoPeloFluxo  = errors.New("para desligar o colaborador use POST /api/admin/colaboradores/:id/desligamento")
+)
+
+// Por quanto tempo o status fica em cache; alterações feitas em outras
+// instâncias também encerram as sessões, então o atraso é limitado
+const statusCacheTTL = 30 * time.Second
+
+// AcessoDoStatus retorna o nível de acesso do status. Status desconhecidos
+// não têm acesso.
+func AcessoDoStatus(status string) Acesso {
+	acesso, ok := regrasStatus[strings.ToLower(strings.TrimSpace(status))]
+	if !ok {
+		return AcessoNegado
+	}
+	return acesso
+}
+
+type statusEmCache struct {
+	status      string
+	carregadoEm time.Time
+}
+
+// StatusColaboradorService consulta o status dos colaboradores a cada
+// requisição (com cache) e aplica as alterações feitas pelos administradores
+type StatusColaboradorService struct {
+	colaboradorRepo *repository.ColaboradorRepository
+	sessoes         *SessaoService
+	personificacoes *PersonificacaoService
+
+	mu    sync.RWMutex
+	cache map[int]statusEmCache
+}
+
+func NewStatusColaboradorService(
+	colaboradorRepo *repository.ColaboradorRepository,
+	sessoes *SessaoService,
+	personificacoes *PersonificacaoService,
+) *StatusColaboradorService {
+	return &StatusColaboradorService{
+		colaboradorRepo: colaboradorRepo,
+		sessoes:         sessoes,
+		personificacoes: personificacoes,
+		cache:           map[int]statusEmCache{},
+	}
+}
+
+// Acesso retorna o nível de acesso atual do colaborador
+func (s *StatusColaboradorService) Acesso(colaboradorID int) (Acesso, error) {
+	s.mu.RLock()
+	item, ok := s.cache[colaboradorID]
+	s.mu.RUnlock()
+
+	if !ok || time.Since(item.carregadoEm) > statusCacheTTL {
+		status, err := s.colaboradorRepo.GetStatus(colaboradorID)
+		if err != nil {
+			return AcessoNegado, err
+		}
+
+		item = statusEmCache{status: status, carregadoEm: time.Now()}
+		s.mu.Lock()
+		s.cache[colaboradorID] = item
+		s.mu.Unlock()
+	}
+
+	return AcessoDoStatus(item.status), nil
+}
+
+// Alterar muda o status do colaborador e encerra imediatamente todas as suas
+// sessões, para que o novo status valha a partir do próximo login. O
+// desligamento não passa por aqui: ele tem fluxo próprio (DesligamentoService),
+// que também repassa os subordinados e registra o relatório.
+func (s *StatusColaboradorService) Alterar(colaboradorID int, status string, alteradoPor int) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if _, ok := regrasStatus[status]; !ok {
+		return ErrStatusInvalido
+	}
+	if status == StatusDesligado {
+		return ErrDesligamentoPeloFluxo
+	}
+
+	anonimizado, err := s.colaboradorRepo.IsAnonimizado(colaboradorID)
+	if errors.Is(err, repository.ErrColaboradorNaoEncontrado) {
+		return ErrColaboradorNaoEncontrado
+	}
+	if err != nil {
+		return err
+	}
+	if anonimizado {
+		return ErrColaboradorAnonimizado
+	}
+
+	err = s.colaboradorRepo.UpdateStatus(colaboradorID, status, alteradoPor)
+	if errors.Is(err, repository.ErrColaboradorNaoEncontrado) {
+		return ErrColaboradorNaoEncontrado
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.EncerrarAcesso(colaboradorID)
+}
+
+// EncerrarAcesso descarta o status em cache e encerra todas as sessões do
+// colaborador e as personificações que ele iniciou como administrador. Usado
+// também quando o status é alterado em outra transação, como no desligamento.
+func (s *StatusColaboradorService) EncerrarAcesso(colaboradorID int) error {
+	s.mu.Lock()
+	delete(s.cache, colaboradorID)
+	s.mu.Unlock()
+
+	if err := s.personificacoes.EncerrarDoAtor(colaboradorID); err != nil {
+		return err
+	}
+
+	return s.sessoes.EncerrarTodas(colaboradorID)
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"empresa-app/backend/internal/model"
+	"empresa-app/backend/internal/repository"
+	"empresa-app/backend/pkg/config"
+)
+
+// Resultados registrados no histórico de login
+const (
+	LoginSucesso    = "sucesso"
+	LoginFalha      = "falha"
+	LoginBloqueado  = "bloqueado"
+	LoginDesafio2FA = "desafio_2fa"
+	LoginFalha2FA   = "falha_2fa"
+	LoginSemAcesso  = "sem_acesso"
+)
+
+// Espera máxima entre tentativas antes do bloqueio
+const backoffMaximo = time.Minute
+
+// LoginBloqueadoError indica que a conta ou o IP deve aguardar antes de tentar novamente
+type LoginBloqueadoError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginBloqueadoError) Error() string {
+	return fmt.Sprintf("muitas tentativas de login; tente novamente em %d segundos", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// RedefinicaoLimitadaError indica que o email ou o IP excedeu o limite de
+// pedidos de redefinição de senha
+type RedefinicaoLimitadaError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RedefinicaoLimitadaError) Error() string {
+	return fmt.Sprintf("muitos pedidos de redefinição de senha; tente novamente em %d segundos", int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// TentativaLoginService aplica backoff exponencial e bloqueio temporário por
+// conta e por IP, e mantém o histórico de logins
+type TentativaLoginService struct {
+	tentativaRepo   *repository.TentativaLoginRepository
+	colaboradorRepo *repository.ColaboradorRepository
+	config          config.AuthConfig
+}
+
+func NewTentativaLoginService(
+	tentativaRepo *repository.TentativaLoginRepository,
+	colaboradorRepo *repository.ColaboradorRepository,
+	cfg config.AuthConfig,
+) *TentativaLoginService {
+	return &TentativaLoginService{
+		tentativaRepo:   tentativaRepo,
+		colaboradorRepo: colaboradorRepo,
+		config:          cfg,
+	}
+}
+
+// Verificar retorna *LoginBloqueadoError se a conta ou o IP estiverem em
+// espera ou bloqueados. Falhas ao ler os contadores são retornadas, para que
+// o bloqueio não seja ignorado quando o banco está indisponível
+func (s *TentativaLoginService) Verificar(email, ip string) error {
+	now := time.Now()
+	for _, chave := range []string{chaveConta(email), chaveIP(ip)} {
+		tentativa, err := s.tentativaRepo.Get(chave)
+		if errors.Is(err, repository.ErrTentativaNaoEncontrada) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if tentativa.BloqueadoAte != nil && now.Before(*tentativa.BloqueadoAte) {
+			return &LoginBloqueadoError{RetryAfter: tentativa.BloqueadoAte.Sub(now)}
+		}
+
+		// O backoff vale só para a conta; o IP (que pode ser compartilhado
+		// por vários colaboradores) é apenas bloqueado ao atingir o limite
+		if chave == chaveConta(email) && tentativa.Falhas > 0 {
+			liberadoEm := tentativa.UltimaFalha.Add(backoff(tentativa.Falhas))
+			if now.Before(liberadoEm) {
+				return &LoginBloqueadoError{RetryAfter: liberadoEm.Sub(now)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegistrarFalha incrementa os contadores da conta e do IP, bloqueando-os ao
+// atingir o limite configurado. As falhas contam dentro de uma janela de
+// tempo, para que erros esporádicos ao longo de semanas não bloqueiem a conta.
+func (s *TentativaLoginService) RegistrarFalha(email, ip string) error {
+	limites := map[string]int{
+		chaveConta(email): s.config.MaxTentativasConta,
+		chaveIP(ip):       s.config.MaxTentativasIP,
+	}
+
+	now := time.Now()
+	inicioJanela := now.Add(-time.Duration(s.config.JanelaMinutos) * time.Minute)
+	for chave, limite := range limites {
+		falhas, err := s.tentativaRepo.Increment(chave, now, inicioJanela)
+		if err != nil {
+			return err
+		}
+
+		if falhas >= limite {
+			bloqueio := time.Duration(s.config.BloqueioMinutos) * time.Minute
+			if err := s.tentativaRepo.Lock(chave, now.Add(bloqueio)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegistrarSucesso zera o contador da conta e desconta uma falha do IP. O
+// contador do IP não é zerado para que um login válido não libere novas
+// tentativas contra outras contas a partir do mesmo endereço.
+func (s *TentativaLoginService) RegistrarSucesso(email, ip string) error {
+	if err := s.tentativaRepo.Reset(chaveConta(email)); err != nil {
+		return err
+	}
+	return s.tentativaRepo.Decrement(chaveIP(ip))
+}
+
+// LimitarRedefinicao conta um pedido de redefinição de senha para o email e
+// para o IP e retorna *RedefinicaoLimitadaError se algum deles estiver
+// bloqueado. Ao atingir o limite, a chave fica bloqueada até o fim da janela.
+// O email conta mesmo que não esteja cadastrado, para que o limite não revele
+// quais contas existem.
+func (s *TentativaLoginService) LimitarRedefinicao(email, ip string) error {
+	limites := map[string]int{
+		chaveRedefinicaoEmail(email): s.config.MaxRedefinicoesEmail,
+		chaveRedefinicaoIP(ip):       s.config.MaxRedefinicoesIP,
+	}
+
+	now := time.Now()
+	for chave := range limites {
+		tentativa, err := s.tentativaRepo.Get(chave)
+		if errors.Is(err, repository.ErrTentativaNaoEncontrada) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if tentativa.BloqueadoAte != nil && now.Before(*tentativa.BloqueadoAte) {
+			return &RedefinicaoLimitadaError{RetryAfter: tentativa.BloqueadoAte.Sub(now)}
+		}
+	}
+
+	janela := time.Duration(s.config.JanelaMinutos) * time.Minute
+	for chave, limite := range limites {
+		pedidos, err := s.tentativaRepo.Increment(chave, now, now.Add(-janela))
+		if err != nil {
+			return err
+		}
+
+		if pedidos >= limite {
+			if err := s.tentativaRepo.Lock(chave, now.Add(janela)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Desbloquear remove o bloqueio da conta do colaborador
+func (s *TentativaLoginService) Desbloquear(colaboradorID int) error {
+	colaborador, err := s.colaboradorRepo.GetByID(colaboradorID)
+	if err != nil {
+		return err
+	}
+
+	return s.tentativaRepo.Reset(chaveConta(colaborador.Email))
+}
+
+// RegistrarHistorico grava a tentativa no histórico; falhas de gravação são
+// apenas registradas no log para não impedir o login
+func (s *TentativaLoginService) RegistrarHistorico(colaboradorID *int, email, ip, userAgent, resultado string) {
+	err := s.tentativaRepo.CreateHistorico(&model.HistoricoLogin{
+		ColaboradorID: colaboradorID,
+		Email:         strings.ToLower(email),
+		IP:            ip,
+		UserAgent:     userAgent,
+		Resultado:     resultado,
+	})
+	if err != nil {
+		log.Printf("Erro ao registrar histórico de login: %v", err)
+	}
+}
+
+// ListarHistorico retorna o histórico de login do colaborador
+func (s *TentativaLoginService) ListarHistorico(colaboradorID, limit, offset int) ([]*model.HistoricoLogin, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.tentativaRepo.ListHistorico(colaboradorID, limit, offset)
+}
+
+// backoff retorna a espera exigida após n falhas consecutivas: 1s, 2s, 4s...
+func backoff(falhas int) time.Duration {
+	if falhas > 6 {
+		return backoffMaximo
+	}
+
+	espera := time.Duration(1<<uint(falhas-1)) * time.Second
+	if espera > backoffMaximo {
+		return backoffMaximo
+	}
+	return espera
+}
+
+func chaveConta(email string) string {
+	return "conta:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func chaveIP(ip string) string {
+	return "ip:" + ip
+}
+
+func chaveRedefinicaoEmail(email string) string {
+	return "redefinicao:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func chaveRedefinicaoIP(ip string) string {
+	return "redefinicao_ip:" + ip
+}
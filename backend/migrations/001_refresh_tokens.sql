@@ -0,0 +1,17 @@
+-- Refresh tokens de longa duração, de uso único e rotacionados a cada uso.
+-- Tokens da mesma cadeia de rotação compartilham a mesma família; o reuso de
+-- um token já consumido revoga a família inteira.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id              SERIAL PRIMARY KEY,
+    usuario_id      INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    token_hash      VARCHAR(64) NOT NULL UNIQUE,
+    familia         UUID NOT NULL,
+    expira_em       TIMESTAMP NOT NULL,
+    usado_em        TIMESTAMP,
+    revogado_em     TIMESTAMP,
+    substituido_por INTEGER REFERENCES refresh_tokens(id),
+    criado_em       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_familia ON refresh_tokens (familia);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_usuario ON refresh_tokens (usuario_id);
@@ -0,0 +1,17 @@
+-- Lista de revogação de tokens de acesso (JWT) por jti.
+-- As entradas podem ser removidas após expira_em, quando o próprio JWT já expirou.
+CREATE TABLE IF NOT EXISTS tokens_revogados (
+    jti         UUID PRIMARY KEY,
+    usuario_id  INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    expira_em   TIMESTAMP NOT NULL,
+    revogado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tokens_revogados_expira_em ON tokens_revogados (expira_em);
+
+-- Revogação de todos os tokens de um colaborador emitidos até revogado_em.
+CREATE TABLE IF NOT EXISTS revogacoes_colaborador (
+    usuario_id  INTEGER PRIMARY KEY REFERENCES usuarios(id) ON DELETE CASCADE,
+    revogado_em TIMESTAMP NOT NULL,
+    expira_em   TIMESTAMP NOT NULL
+);
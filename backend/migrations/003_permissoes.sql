@@ -0,0 +1,33 @@
+-- Permissões atribuídas a cargos (RBAC).
+CREATE TABLE IF NOT EXISTS permissoes (
+    id        SERIAL PRIMARY KEY,
+    codigo    VARCHAR(100) NOT NULL UNIQUE,
+    descricao VARCHAR(255) NOT NULL DEFAULT '',
+    criado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS cargo_permissoes (
+    cargo_id      INTEGER NOT NULL REFERENCES cargos(id) ON DELETE CASCADE,
+    permissao_id  INTEGER NOT NULL REFERENCES permissoes(id) ON DELETE CASCADE,
+    concedido_por INTEGER REFERENCES usuarios(id),
+    criado_em     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cargo_id, permissao_id)
+);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('documentos.ver_todos', 'Visualizar documentos de todos os colaboradores'),
+    ('documentos.aprovar', 'Aprovar ou rejeitar documentos'),
+    ('documentos.enviar', 'Enviar documentos aprovados para finanças'),
+    ('ponto.ver_equipe', 'Visualizar registros de ponto de outros colaboradores'),
+    ('sessoes.revogar', 'Revogar tokens e sessões de colaboradores'),
+    ('permissoes.gerenciar', 'Gerenciar permissões dos cargos')
+ON CONFLICT (codigo) DO NOTHING;
+
+-- Os cargos 2, 5 e 6 eram tratados como administradores no código;
+-- mantêm todas as permissões existentes.
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT c.id, p.id
+FROM cargos c
+CROSS JOIN permissoes p
+WHERE c.id IN (2, 5, 6)
+ON CONFLICT DO NOTHING;
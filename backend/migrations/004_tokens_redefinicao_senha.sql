@@ -0,0 +1,11 @@
+-- Tokens de uso único para redefinição de senha (apenas o hash SHA-256 é armazenado).
+CREATE TABLE IF NOT EXISTS tokens_redefinicao_senha (
+    id         SERIAL PRIMARY KEY,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expira_em  TIMESTAMP NOT NULL,
+    usado_em   TIMESTAMP,
+    criado_em  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tokens_redefinicao_usuario ON tokens_redefinicao_senha (usuario_id);
@@ -0,0 +1,32 @@
+-- Autenticação em dois fatores (TOTP, RFC 6238).
+CREATE TABLE IF NOT EXISTS dois_fatores (
+    usuario_id   INTEGER PRIMARY KEY REFERENCES usuarios(id) ON DELETE CASCADE,
+    segredo      VARCHAR(64) NOT NULL,
+    ativado_em   TIMESTAMP,
+    ultimo_passo BIGINT NOT NULL DEFAULT 0,
+    criado_em    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Códigos de recuperação de uso único (apenas o hash bcrypt é armazenado).
+CREATE TABLE IF NOT EXISTS codigos_recuperacao (
+    id          SERIAL PRIMARY KEY,
+    usuario_id  INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    codigo_hash VARCHAR(100) NOT NULL,
+    usado_em    TIMESTAMP,
+    criado_em   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_codigos_recuperacao_usuario ON codigos_recuperacao (usuario_id);
+
+-- Política por cargo: 2FA obrigatório.
+ALTER TABLE cargos ADD COLUMN IF NOT EXISTS exige_2fa BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Quem aprova ou envia recibos para finanças passa a exigir 2FA.
+UPDATE cargos
+SET exige_2fa = TRUE
+WHERE id IN (
+    SELECT cp.cargo_id
+    FROM cargo_permissoes cp
+    JOIN permissoes p ON cp.permissao_id = p.id
+    WHERE p.codigo IN ('documentos.aprovar', 'documentos.enviar')
+);
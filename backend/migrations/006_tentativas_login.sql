@@ -0,0 +1,34 @@
+-- Contadores de falhas de login por conta (email) e por IP.
+-- chave: 'conta:<email>' ou 'ip:<endereço>'.
+CREATE TABLE IF NOT EXISTS tentativas_login (
+    chave         VARCHAR(320) PRIMARY KEY,
+    falhas        INTEGER NOT NULL DEFAULT 0,
+    ultima_falha  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    bloqueado_ate TIMESTAMP
+);
+
+-- Histórico de logins, visível ao próprio colaborador.
+CREATE TABLE IF NOT EXISTS historico_login (
+    id         SERIAL PRIMARY KEY,
+    usuario_id INTEGER REFERENCES usuarios(id) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL,
+    ip         VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    resultado  VARCHAR(30) NOT NULL,
+    criado_em  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_historico_login_usuario ON historico_login (usuario_id, criado_em DESC);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('contas.desbloquear', 'Desbloquear contas bloqueadas por excesso de tentativas de login')
+ON CONFLICT (codigo) DO NOTHING;
+
+-- Concedida a quem já pode revogar sessões
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'sessoes.revogar' AND nova.codigo = 'contas.desbloquear'
+ON CONFLICT DO NOTHING;
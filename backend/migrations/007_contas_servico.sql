@@ -0,0 +1,39 @@
+-- Contas de serviço para integrações entre sistemas (ex.: financeiro).
+CREATE TABLE IF NOT EXISTS contas_servico (
+    id            SERIAL PRIMARY KEY,
+    nome          VARCHAR(100) NOT NULL UNIQUE,
+    descricao     VARCHAR(255) NOT NULL DEFAULT '',
+    ativo         BOOLEAN NOT NULL DEFAULT TRUE,
+    criado_por    INTEGER REFERENCES usuarios(id),
+    criado_em     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    atualizado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Chaves de API. O prefixo (público) identifica a chave; apenas o hash
+-- SHA-256 do segredo é armazenado.
+CREATE TABLE IF NOT EXISTS chaves_api (
+    id               SERIAL PRIMARY KEY,
+    conta_servico_id INTEGER NOT NULL REFERENCES contas_servico(id) ON DELETE CASCADE,
+    prefixo          VARCHAR(16) NOT NULL UNIQUE,
+    segredo_hash     VARCHAR(64) NOT NULL,
+    escopos          TEXT[] NOT NULL DEFAULT '{}',
+    expira_em        TIMESTAMP,
+    revogado_em      TIMESTAMP,
+    ultimo_uso_em    TIMESTAMP,
+    criado_por       INTEGER REFERENCES usuarios(id),
+    criado_em        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chaves_api_conta ON chaves_api (conta_servico_id);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('contas_servico.gerenciar', 'Gerenciar contas de serviço e chaves de API')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'contas_servico.gerenciar'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,23 @@
+-- Sessões ativas: uma por login, ligada à família de refresh tokens.
+-- expira_em acompanha a validade do último refresh token emitido.
+CREATE TABLE IF NOT EXISTS sessoes (
+    id               SERIAL PRIMARY KEY,
+    usuario_id       INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    familia          UUID NOT NULL UNIQUE,
+    dispositivo      VARCHAR(100) NOT NULL DEFAULT '',
+    user_agent       TEXT NOT NULL DEFAULT '',
+    ip               VARCHAR(45) NOT NULL DEFAULT '',
+    criado_em        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ultimo_acesso_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expira_em        TIMESTAMP NOT NULL,
+    encerrado_em     TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessoes_usuario ON sessoes (usuario_id) WHERE encerrado_em IS NULL;
+
+-- Revogação dos tokens de acesso de uma sessão encerrada.
+-- As entradas podem ser removidas após expira_em.
+CREATE TABLE IF NOT EXISTS revogacoes_sessao (
+    sessao_id INTEGER PRIMARY KEY REFERENCES sessoes(id) ON DELETE CASCADE,
+    expira_em TIMESTAMP NOT NULL
+);
@@ -0,0 +1,10 @@
+-- Hashes das senhas anteriores, usados para impedir a reutilização.
+-- Apenas as últimas N (SENHA_HISTORICO) são mantidas por colaborador.
+CREATE TABLE IF NOT EXISTS historico_senhas (
+    id         SERIAL PRIMARY KEY,
+    usuario_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    senha_hash VARCHAR(255) NOT NULL,
+    criado_em  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_historico_senhas_usuario ON historico_senhas (usuario_id, criado_em DESC);
@@ -0,0 +1,28 @@
+-- Status do colaborador: ativo, ferias, afastado ou desligado.
+-- Normaliza valores existentes antes de restringir a coluna.
+UPDATE usuarios SET status = LOWER(TRIM(status)) WHERE status IS NOT NULL;
+UPDATE usuarios SET status = 'ativo' WHERE status IS NULL OR status = '';
+UPDATE usuarios SET status = 'desligado' WHERE status = 'inativo';
+UPDATE usuarios SET status = 'ferias' WHERE status = 'férias';
+
+ALTER TABLE usuarios ALTER COLUMN status SET DEFAULT 'ativo';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'usuarios_status_check') THEN
+        ALTER TABLE usuarios ADD CONSTRAINT usuarios_status_check
+            CHECK (status IN ('ativo', 'ferias', 'afastado', 'desligado'));
+    END IF;
+END $$;
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('colaboradores.gerenciar', 'Gerenciar cadastro e status dos colaboradores')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'colaboradores.gerenciar'
+ON CONFLICT DO NOTHING;
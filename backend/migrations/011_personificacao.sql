@@ -0,0 +1,41 @@
+-- Personificação: token de curta duração emitido por um administrador para
+-- ver o sistema como o colaborador alvo. Toda requisição feita com o token é
+-- registrada em requisicoes_personificacao.
+CREATE TABLE IF NOT EXISTS personificacoes (
+    id           SERIAL PRIMARY KEY,
+    ator_id      INTEGER NOT NULL REFERENCES usuarios(id),
+    alvo_id      INTEGER NOT NULL REFERENCES usuarios(id),
+    motivo       TEXT NOT NULL,
+    jti          UUID NOT NULL UNIQUE,
+    ip           VARCHAR(45) NOT NULL DEFAULT '',
+    criado_em    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expira_em    TIMESTAMP NOT NULL,
+    encerrado_em TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_personificacoes_alvo ON personificacoes (alvo_id);
+
+CREATE TABLE IF NOT EXISTS requisicoes_personificacao (
+    id                SERIAL PRIMARY KEY,
+    personificacao_id INTEGER NOT NULL REFERENCES personificacoes(id) ON DELETE CASCADE,
+    metodo            VARCHAR(10) NOT NULL,
+    rota              VARCHAR(255) NOT NULL,
+    caminho           TEXT NOT NULL,
+    status            INTEGER NOT NULL DEFAULT 0, -- 0 enquanto a requisição não termina
+    ip                VARCHAR(45) NOT NULL DEFAULT '',
+    criado_em         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_requisicoes_personificacao ON requisicoes_personificacao (personificacao_id, criado_em);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('colaboradores.personificar', 'Acessar o sistema como outro colaborador para suporte')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'colaboradores.personificar'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+-- Autorizações OIDC em andamento: o state enviado ao provedor identifica o
+-- nonce e o code_verifier (PKCE) que serão usados no retorno. Cada state é
+-- consumido uma única vez e as entradas podem ser removidas após expira_em.
+CREATE TABLE IF NOT EXISTS autorizacoes_oidc (
+    state         VARCHAR(64) PRIMARY KEY,
+    nonce         VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    criado_em     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expira_em     TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_autorizacoes_oidc_expira ON autorizacoes_oidc (expira_em);
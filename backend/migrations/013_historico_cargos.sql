@@ -0,0 +1,26 @@
+-- Histórico de alterações dos cargos: cadastro, edição, exclusão e mudanças
+-- de permissões. cargo_id não referencia cargos para que o histórico de um
+-- cargo excluído seja mantido.
+CREATE TABLE IF NOT EXISTS historico_cargos (
+    id               SERIAL PRIMARY KEY,
+    cargo_id         INTEGER NOT NULL,
+    acao             VARCHAR(30) NOT NULL, -- criado, atualizado, excluido, permissao_concedida, permissao_removida
+    alterado_por     INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    dados_anteriores JSONB,
+    dados_novos      JSONB,
+    criado_em        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_historico_cargos_cargo ON historico_cargos (cargo_id, criado_em);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('cargos.gerenciar', 'Cadastrar, editar e excluir cargos')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'cargos.gerenciar'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+-- Fotos de perfil enviadas pelos colaboradores. Apenas as miniaturas
+-- recodificadas (sem EXIF) são guardadas; usuarios.foto_perfil passa a conter
+-- a URL autenticada da foto, com a versão para invalidar caches.
+CREATE TABLE IF NOT EXISTS fotos_perfil (
+    usuario_id      INTEGER PRIMARY KEY REFERENCES usuarios(id) ON DELETE CASCADE,
+    caminho_grande  TEXT NOT NULL,
+    caminho_pequeno TEXT NOT NULL,
+    versao          VARCHAR(16) NOT NULL,
+    atualizado_em   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
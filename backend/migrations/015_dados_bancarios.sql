@@ -0,0 +1,25 @@
+-- Dados bancários: passam a ser gravados cifrados (envelope JSON em
+-- usuarios.dados_bancarios; os registros antigos são cifrados pela API ao
+-- iniciar) e toda consulta dos dados completos fica registrada aqui.
+CREATE TABLE IF NOT EXISTS revelacoes_dados_bancarios (
+    id             SERIAL PRIMARY KEY,
+    colaborador_id INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    revelado_por   INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    motivo         TEXT NOT NULL,
+    ip             VARCHAR(45) NOT NULL DEFAULT '',
+    criado_em      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_revelacoes_dados_bancarios ON revelacoes_dados_bancarios (colaborador_id, criado_em);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('dados_bancarios.revelar', 'Ver os dados bancários completos dos colaboradores (financeiro)')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'dados_bancarios.revelar'
+ON CONFLICT DO NOTHING;
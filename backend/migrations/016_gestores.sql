@@ -0,0 +1,17 @@
+-- Hierarquia: cada colaborador pode ter um gestor direto. Os documentos
+-- enviados vão primeiro para o gestor de quem enviou (aprovador_id); sem
+-- gestor, ficam com o RH (permissão documentos.aprovar).
+ALTER TABLE usuarios ADD COLUMN IF NOT EXISTS gestor_id INTEGER REFERENCES usuarios(id) ON DELETE SET NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'usuarios_gestor_check') THEN
+        ALTER TABLE usuarios ADD CONSTRAINT usuarios_gestor_check CHECK (gestor_id <> id);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_usuarios_gestor ON usuarios (gestor_id);
+
+ALTER TABLE recibos ADD COLUMN IF NOT EXISTS aprovador_id INTEGER REFERENCES usuarios(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_recibos_aprovador_pendentes ON recibos (aprovador_id) WHERE status = 'pendente';
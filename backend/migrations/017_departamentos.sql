@@ -0,0 +1,55 @@
+-- Departamentos e centros de custo. O colaborador é alocado a um departamento
+-- e/ou centro de custo a partir de uma data (vigencia_inicio); a alocação
+-- vale até o dia anterior ao início da seguinte (vigencia_fim, inclusiva,
+-- nula enquanto for a atual). Documentos e pontos são atribuídos à alocação
+-- vigente na data do registro.
+CREATE TABLE IF NOT EXISTS departamentos (
+    id            SERIAL PRIMARY KEY,
+    nome          VARCHAR(100) NOT NULL,
+    descricao     TEXT NOT NULL DEFAULT '',
+    ativo         BOOLEAN NOT NULL DEFAULT TRUE,
+    criado_em     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    atualizado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_departamentos_nome ON departamentos (LOWER(nome));
+
+CREATE TABLE IF NOT EXISTS centros_custo (
+    id            SERIAL PRIMARY KEY,
+    codigo        VARCHAR(30) NOT NULL,
+    nome          VARCHAR(100) NOT NULL,
+    ativo         BOOLEAN NOT NULL DEFAULT TRUE,
+    criado_em     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    atualizado_em TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_centros_custo_codigo ON centros_custo (LOWER(codigo));
+
+CREATE TABLE IF NOT EXISTS alocacoes_colaborador (
+    id              SERIAL PRIMARY KEY,
+    usuario_id      INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    departamento_id INTEGER REFERENCES departamentos(id),
+    centro_custo_id INTEGER REFERENCES centros_custo(id),
+    vigencia_inicio DATE NOT NULL,
+    vigencia_fim    DATE,
+    criado_por      INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    criado_em       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT alocacoes_colaborador_vigencia_check CHECK (vigencia_fim IS NULL OR vigencia_fim >= vigencia_inicio),
+    CONSTRAINT alocacoes_colaborador_destino_check CHECK (departamento_id IS NOT NULL OR centro_custo_id IS NOT NULL),
+    UNIQUE (usuario_id, vigencia_inicio)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alocacoes_colaborador_departamento ON alocacoes_colaborador (departamento_id, vigencia_inicio);
+CREATE INDEX IF NOT EXISTS idx_alocacoes_colaborador_centro_custo ON alocacoes_colaborador (centro_custo_id, vigencia_inicio);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('departamentos.gerenciar', 'Cadastrar departamentos e centros de custo')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'departamentos.gerenciar'
+ON CONFLICT DO NOTHING;
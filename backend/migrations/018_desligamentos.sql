@@ -0,0 +1,16 @@
+-- Desligamentos: registro de cada desligamento com o checklist do que foi
+-- feito (status, sessões, documentos, subordinados, alocação) e o espelho do
+-- ponto do período, guardado para o acerto final.
+CREATE TABLE IF NOT EXISTS desligamentos (
+    id                SERIAL PRIMARY KEY,
+    usuario_id        INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    data_desligamento DATE NOT NULL,
+    motivo            TEXT NOT NULL,
+    substituto_id     INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    realizado_por     INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    checklist         JSONB NOT NULL DEFAULT '[]',
+    espelho_ponto     JSONB,
+    criado_em         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_desligamentos_usuario ON desligamentos (usuario_id, criado_em);
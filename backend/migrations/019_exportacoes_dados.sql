@@ -0,0 +1,19 @@
+-- Exportações de dados pessoais (LGPD) pedidas pelo próprio colaborador. O
+-- ZIP é gerado em segundo plano e fica disponível por link assinado até
+-- expira_em; depois disso o arquivo é apagado (status expirada).
+CREATE TABLE IF NOT EXISTS exportacoes_dados (
+    id              SERIAL PRIMARY KEY,
+    usuario_id      INTEGER NOT NULL REFERENCES usuarios(id) ON DELETE CASCADE,
+    status          VARCHAR(20) NOT NULL DEFAULT 'processando', -- processando, concluida, falhou, expirada
+    caminho_arquivo TEXT,
+    tamanho_bytes   BIGINT,
+    erro            TEXT,
+    expira_em       TIMESTAMP,
+    criado_em       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    concluido_em    TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exportacoes_dados_usuario ON exportacoes_dados (usuario_id, criado_em);
+
+-- Uma exportação em andamento por colaborador
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exportacoes_dados_processando ON exportacoes_dados (usuario_id) WHERE status = 'processando';
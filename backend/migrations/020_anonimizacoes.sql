@@ -0,0 +1,40 @@
+-- Anonimização (LGPD): passado o prazo de retenção após o desligamento, os
+-- dados pessoais do colaborador são apagados. O registro em usuarios é
+-- mantido sob um pseudônimo para preservar os valores e datas dos recibos e
+-- as horas de ponto; anonimizado_em impede que ele volte a ser ativado.
+ALTER TABLE usuarios ADD COLUMN IF NOT EXISTS anonimizado_em TIMESTAMP;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'usuarios_anonimizado_check') THEN
+        ALTER TABLE usuarios ADD CONSTRAINT usuarios_anonimizado_check
+            CHECK (anonimizado_em IS NULL OR status = 'desligado');
+    END IF;
+END $$;
+
+-- Registro de cada anonimização, sem dados pessoais: apenas o pseudônimo e
+-- a quantidade de registros apagados. realizado_por nulo indica a rotina
+-- agendada.
+CREATE TABLE IF NOT EXISTS anonimizacoes (
+    id                SERIAL PRIMARY KEY,
+    usuario_id        INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    pseudonimo        VARCHAR(20) NOT NULL,
+    data_desligamento DATE NOT NULL,
+    resumo            JSONB NOT NULL DEFAULT '{}',
+    realizado_por     INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    criado_em         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_anonimizacoes_criado_em ON anonimizacoes (criado_em);
+
+INSERT INTO permissoes (codigo, descricao) VALUES
+    ('colaboradores.anonimizar', 'Anonimizar os dados pessoais de ex-colaboradores após o prazo de retenção (LGPD)')
+ON CONFLICT (codigo) DO NOTHING;
+
+INSERT INTO cargo_permissoes (cargo_id, permissao_id)
+SELECT cp.cargo_id, nova.id
+FROM cargo_permissoes cp
+JOIN permissoes p ON cp.permissao_id = p.id
+CROSS JOIN permissoes nova
+WHERE p.codigo = 'permissoes.gerenciar' AND nova.codigo = 'colaboradores.anonimizar'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Histórico de alterações do cadastro dos colaboradores: cada registro guarda
+-- quem alterou, quando, quais campos mudaram e os valores anteriores e novos
+-- desses campos. Dados bancários aparecem apenas como alterados, sem valor.
+-- usuario_id não referencia usuarios para que o histórico seja mantido.
+CREATE TABLE IF NOT EXISTS historico_colaboradores (
+    id               SERIAL PRIMARY KEY,
+    usuario_id       INTEGER NOT NULL,
+    acao             VARCHAR(30) NOT NULL, -- criado, atualizado, desligado, anonimizado
+    campos           TEXT[] NOT NULL,
+    alterado_por     INTEGER REFERENCES usuarios(id) ON DELETE SET NULL,
+    dados_anteriores JSONB,
+    dados_novos      JSONB,
+    criado_em        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_historico_colaboradores_usuario ON historico_colaboradores (usuario_id, criado_em);
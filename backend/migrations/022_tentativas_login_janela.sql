@@ -0,0 +1,3 @@
+-- Os contadores de falhas de login passam a valer por uma janela de tempo:
+-- uma falha após o fim da janela iniciada em primeira_falha recomeça a contagem.
+ALTER TABLE tentativas_login ADD COLUMN IF NOT EXISTS primeira_falha TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -5,12 +5,21 @@ import (
 	"strconv"
 )
 
+// Valores padrão de desenvolvimento; não são aceitos em produção
+const (
+	DefaultJWTSecret     = "seu_segredo_jwt_aqui"
+	DefaultRefreshSecret = "seu_segredo_refresh_aqui"
+)
+
 // Config contém todas as configurações da aplicação
 type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
 	Auth     AuthConfig
 	Storage  StorageConfig
+	Mail     MailConfig
+	Cripto   CriptoConfig
+	LGPD     LGPDConfig
 }
 
 // DatabaseConfig contém as configurações do banco de dados
@@ -25,6 +34,7 @@ type DatabaseConfig struct {
 
 // ServerConfig contém as configurações do servidor HTTP
 type ServerConfig struct {
+	Env          string // development ou production
 	Port         string
 	ReadTimeout  int
 	WriteTimeout int
@@ -33,10 +43,50 @@ type ServerConfig struct {
 
 // AuthConfig contém as configurações de autenticação
 type AuthConfig struct {
-	JWTSecret         string
-	TokenExpiration   int
+	JWTSecret         string // usado apenas em desenvolvimento, sem chaves assimétricas
+	JWTKeysDir        string // diretório com as chaves PEM (<kid>.pem)
+	JWTActiveKeyID    string // kid da chave usada para assinar
+	JWTIssuer         string // claim iss dos tokens emitidos
+	TokenExpiration   int    // em minutos
 	RefreshSecret     string
-	RefreshExpiration int
+	RefreshExpiration int // em horas
+	ResetExpiration   int // em minutos
+	ConviteExpiration int // em horas
+
+	// Proteção contra força bruta no login
+	MaxTentativasConta int
+	MaxTentativasIP    int
+	BloqueioMinutos    int
+	JanelaMinutos      int // falhas mais antigas que a janela deixam de contar
+
+	// Limite de pedidos de redefinição de senha por email e por IP na janela
+	// de JanelaMinutos
+	MaxRedefinicoesEmail int
+	MaxRedefinicoesIP    int
+
+	// Política de senhas
+	SenhaTamanhoMinimo int
+	SenhaListaVazadas  string // arquivo com senhas vazadas, uma por linha
+	SenhaHistorico     int    // senhas anteriores que não podem ser reutilizadas
+
+	// Hash de senhas: novos hashes usam SenhaAlgoritmo; hashes antigos são
+	// refeitos no próximo login
+	SenhaAlgoritmo    string // argon2id ou bcrypt
+	Argon2Memoria     int    // em KiB
+	Argon2Iteracoes   int
+	Argon2Paralelismo int
+	BcryptCusto       int
+
+	PersonificacaoDuracao int // em minutos
+
+	// Login único pelo provedor de identidade da empresa (OIDC). Desativado
+	// quando OIDCIssuer está vazio.
+	OIDCIssuer          string
+	OIDCClientID        string
+	OIDCClientSecret    string
+	OIDCRedirectURL     string // deve apontar para /api/auth/oidc/callback
+	OIDCAutoProvisionar bool   // cria o colaborador no primeiro login
+	OIDCCargoPadrao     int    // cargo dos colaboradores criados automaticamente
 }
 
 // StorageConfig contém as configurações de armazenamento de arquivos
@@ -46,6 +96,30 @@ type StorageConfig struct {
 	AllowedTypes []string
 }
 
+// MailConfig contém as configurações de envio de email
+type MailConfig struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	AppURL       string // base dos links enviados por email
+}
+
+// CriptoConfig contém as chaves mestras usadas para cifrar dados sensíveis
+// gravados no banco (dados bancários)
+type CriptoConfig struct {
+	ChavesArquivo string // arquivo com as chaves mestras, uma <id>:<base64> por linha
+	ChaveAtiva    string // id da chave usada para cifrar; vazio usa a última do arquivo
+}
+
+// LGPDConfig contém o prazo de retenção dos dados pessoais de ex-colaboradores
+type LGPDConfig struct {
+	RetencaoDias          int  // após o desligamento, até a anonimização
+	AnonimizacaoIntervalo int  // em horas, entre as execuções da rotina agendada
+	AnonimizacaoSimular   bool // a rotina agendada apenas registra no log o que faria
+}
+
 // Load carrega todas as configurações do ambiente
 func Load() *Config {
 	return &Config{
@@ -58,16 +132,49 @@ func Load() *Config {
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
 		Server: ServerConfig{
+			Env:          getEnv("APP_ENV", "development"),
 			Port:         getEnv("PORT", "8080"),
 			ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 15),
 			WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 15),
 			IdleTimeout:  getEnvAsInt("SERVER_IDLE_TIMEOUT", 60),
 		},
 		Auth: AuthConfig{
-			JWTSecret:         getEnv("JWT_SECRET", "seu_segredo_jwt_aqui"),
-			TokenExpiration:   getEnvAsInt("TOKEN_EXPIRATION", 24),
-			RefreshSecret:     getEnv("REFRESH_SECRET", "seu_segredo_refresh_aqui"),
-			RefreshExpiration: getEnvAsInt("REFRESH_EXPIRATION", 168),
+			JWTSecret:         getEnv("JWT_SECRET", DefaultJWTSecret),
+			JWTKeysDir:        getEnv("JWT_KEYS_DIR", ""),
+			JWTActiveKeyID:    getEnv("JWT_ACTIVE_KID", ""),
+			JWTIssuer:         getEnv("JWT_ISSUER", "empresa-app"),
+			TokenExpiration:   getEnvAsInt("TOKEN_EXPIRATION", 15),
+			RefreshSecret:     getEnv("REFRESH_SECRET", DefaultRefreshSecret),
+			RefreshExpiration: getEnvAsInt("REFRESH_EXPIRATION", 720),
+			ResetExpiration:   getEnvAsInt("RESET_EXPIRATION", 60),
+			ConviteExpiration: getEnvAsInt("CONVITE_EXPIRATION", 72),
+
+			MaxTentativasConta: getEnvAsInt("LOGIN_MAX_TENTATIVAS_CONTA", 5),
+			MaxTentativasIP:    getEnvAsInt("LOGIN_MAX_TENTATIVAS_IP", 20),
+			BloqueioMinutos:    getEnvAsInt("LOGIN_BLOQUEIO_MINUTOS", 15),
+			JanelaMinutos:      getEnvAsInt("LOGIN_JANELA_MINUTOS", 60),
+
+			MaxRedefinicoesEmail: getEnvAsInt("REDEFINICAO_MAX_EMAIL", 3),
+			MaxRedefinicoesIP:    getEnvAsInt("REDEFINICAO_MAX_IP", 10),
+
+			SenhaTamanhoMinimo: getEnvAsInt("SENHA_TAMANHO_MINIMO", 8),
+			SenhaListaVazadas:  getEnv("SENHA_LISTA_VAZADAS", ""),
+			SenhaHistorico:     getEnvAsInt("SENHA_HISTORICO", 5),
+
+			SenhaAlgoritmo:    getEnv("SENHA_ALGORITMO", "argon2id"),
+			Argon2Memoria:     getEnvAsInt("ARGON2_MEMORIA", 19456),
+			Argon2Iteracoes:   getEnvAsInt("ARGON2_ITERACOES", 2),
+			Argon2Paralelismo: getEnvAsInt("ARGON2_PARALELISMO", 1),
+			BcryptCusto:       getEnvAsInt("BCRYPT_CUSTO", 12),
+
+			PersonificacaoDuracao: getEnvAsInt("PERSONIFICACAO_DURACAO", 30),
+
+			OIDCIssuer:          getEnv("OIDC_ISSUER", ""),
+			OIDCClientID:        getEnv("OIDC_CLIENT_ID", ""),
+			OIDCClientSecret:    getEnv("OIDC_CLIENT_SECRET", ""),
+			OIDCRedirectURL:     getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/auth/oidc/callback"),
+			OIDCAutoProvisionar: getEnvAsBool("OIDC_AUTO_PROVISIONAR", false),
+			OIDCCargoPadrao:     getEnvAsInt("OIDC_CARGO_PADRAO", 0),
 		},
 		Storage: StorageConfig{
 			UploadDir:   getEnv("UPLOAD_DIR", "uploads"),
@@ -78,9 +185,31 @@ func Load() *Config {
 				"image/png",
 			},
 		},
+		Mail: MailConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "1025"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("MAIL_FROM", "nao-responda@rlsautomacao.com.br"),
+			AppURL:       getEnv("APP_URL", "http://localhost:8080"),
+		},
+		Cripto: CriptoConfig{
+			ChavesArquivo: getEnv("CHAVES_MESTRAS_ARQUIVO", ""),
+			ChaveAtiva:    getEnv("CHAVE_MESTRA_ATIVA", ""),
+		},
+		LGPD: LGPDConfig{
+			RetencaoDias:          getEnvAsInt("LGPD_RETENCAO_DIAS", 5*365),
+			AnonimizacaoIntervalo: getEnvAsInt("LGPD_ANONIMIZACAO_INTERVALO", 24),
+			AnonimizacaoSimular:   getEnvAsBool("LGPD_ANONIMIZACAO_SIMULAR", true),
+		},
 	}
 }
 
+// IsProduction informa se a aplicação está em modo de produção
+func (c *Config) IsProduction() bool {
+	return c.Server.Env == "production"
+}
+
 // Helpers para obter variáveis de ambiente
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -97,6 +226,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 func getEnvAsInt64(key string, defaultValue int64) int64 {
 	valueStr := getEnv(key, "")
 	if value, err := strconv.ParseInt(valueStr, 10, 64); err == nil {
@@ -0,0 +1,239 @@
+// Package envelope implementa criptografia em envelope: cada registro é
+// cifrado com AES-256-GCM usando uma chave de dados própria, e a chave de
+// dados é guardada cifrada (embrulhada) por uma chave mestra identificada por
+// id. A rotação da chave mestra só precisa reembrulhar as chaves de dados.
+package envelope
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Versão do formato do envelope
+const versao = 1
+
+const tamanhoChave = 32
+
+var ErrEnvelopeInvalido = errors.New("envelope inválido ou chave incorreta")
+
+// Envelope é o formato persistido. É JSON para poder ser gravado tanto em
+// colunas BYTEA quanto JSONB.
+type Envelope struct {
+	Versao       int    `json:"v"`
+	ChaveMestra  string `json:"kid"`
+	ChaveDados   []byte `json:"dek"` // chave de dados cifrada pela chave mestra (nonce + texto cifrado)
+	TextoCifrado []byte `json:"ct"`  // nonce + texto cifrado com a chave de dados
+}
+
+// Chaveiro guarda as chaves mestras. Todas podem abrir envelopes; apenas a
+// ativa é usada para cifrar.
+type Chaveiro struct {
+	chaves map[string][]byte
+	ativa  string
+}
+
+// Carregar lê o arquivo de chaves mestras. Cada linha tem o formato
+// <id>:<chave de 32 bytes em base64> (gere com: openssl rand -base64 32);
+// linhas vazias e iniciadas por # são ignoradas. Sem ativa informada, a
+// última chave do arquivo é a ativa.
+func Carregar(caminho, ativa string) (*Chaveiro, error) {
+	file, err := os.Open(caminho)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	chaveiro := &Chaveiro{chaves: map[string][]byte{}}
+	ultima := ""
+
+	scanner := bufio.NewScanner(file)
+	for linha := 1; scanner.Scan(); linha++ {
+		texto := strings.TrimSpace(scanner.Text())
+		if texto == "" || strings.HasPrefix(texto, "#") {
+			continue
+		}
+
+		id, valor, ok := strings.Cut(texto, ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("linha %d do arquivo de chaves: use <id>:<chave em base64>", linha)
+		}
+
+		chave, err := base64.StdEncoding.DecodeString(strings.TrimSpace(valor))
+		if err != nil || len(chave) != tamanhoChave {
+			return nil, fmt.Errorf("linha %d do arquivo de chaves: a chave %s deve ter %d bytes em base64", linha, id, tamanhoChave)
+		}
+		if _, existe := chaveiro.chaves[id]; existe {
+			return nil, fmt.Errorf("chave %s repetida no arquivo de chaves", id)
+		}
+
+		chaveiro.chaves[id] = chave
+		ultima = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if ativa == "" {
+		ativa = ultima
+	}
+	if _, ok := chaveiro.chaves[ativa]; !ok {
+		return nil, fmt.Errorf("chave mestra ativa %q não encontrada no arquivo", ativa)
+	}
+	chaveiro.ativa = ativa
+
+	return chaveiro, nil
+}
+
+// NovoChaveiro cria um chaveiro com uma única chave, já em memória
+func NovoChaveiro(id string, chave []byte) (*Chaveiro, error) {
+	if len(chave) != tamanhoChave {
+		return nil, fmt.Errorf("a chave mestra deve ter %d bytes", tamanhoChave)
+	}
+	return &Chaveiro{chaves: map[string][]byte{id: chave}, ativa: id}, nil
+}
+
+// Ativa retorna o id da chave mestra usada para cifrar
+func (c *Chaveiro) Ativa() string {
+	return c.ativa
+}
+
+// Cifrar cifra o texto com uma nova chave de dados. O contexto (ex.: o id do
+// registro) é autenticado junto, impedindo que o envelope seja copiado para
+// outro registro.
+func (c *Chaveiro) Cifrar(texto, contexto []byte) ([]byte, error) {
+	chaveDados := make([]byte, tamanhoChave)
+	if _, err := rand.Read(chaveDados); err != nil {
+		return nil, err
+	}
+
+	textoCifrado, err := selar(chaveDados, texto, contexto)
+	if err != nil {
+		return nil, err
+	}
+
+	embrulhada, err := selar(c.chaves[c.ativa], chaveDados, []byte(c.ativa))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(Envelope{
+		Versao:       versao,
+		ChaveMestra:  c.ativa,
+		ChaveDados:   embrulhada,
+		TextoCifrado: textoCifrado,
+	})
+}
+
+// Decifrar abre o envelope com a chave mestra indicada nele
+func (c *Chaveiro) Decifrar(dados, contexto []byte) ([]byte, error) {
+	env, chaveDados, err := c.abrirChaveDados(dados)
+	if err != nil {
+		return nil, err
+	}
+
+	texto, err := abrir(chaveDados, env.TextoCifrado, contexto)
+	if err != nil {
+		return nil, ErrEnvelopeInvalido
+	}
+
+	return texto, nil
+}
+
+// Reembrulhar troca a chave mestra do envelope pela ativa, sem decifrar o
+// conteúdo. Retorna false se o envelope já usa a chave ativa.
+func (c *Chaveiro) Reembrulhar(dados []byte) ([]byte, bool, error) {
+	env, chaveDados, err := c.abrirChaveDados(dados)
+	if err != nil {
+		return nil, false, err
+	}
+	if env.ChaveMestra == c.ativa {
+		return dados, false, nil
+	}
+
+	embrulhada, err := selar(c.chaves[c.ativa], chaveDados, []byte(c.ativa))
+	if err != nil {
+		return nil, false, err
+	}
+
+	env.ChaveMestra = c.ativa
+	env.ChaveDados = embrulhada
+
+	novo, err := json.Marshal(env)
+	if err != nil {
+		return nil, false, err
+	}
+	return novo, true, nil
+}
+
+// EhEnvelope informa se os dados estão no formato de envelope, o que
+// permite identificar registros antigos ainda em texto puro
+func EhEnvelope(dados []byte) bool {
+	var env Envelope
+	return json.Unmarshal(dados, &env) == nil && env.Versao == versao && env.ChaveMestra != "" &&
+		len(env.ChaveDados) > 0 && len(env.TextoCifrado) > 0
+}
+
+func (c *Chaveiro) abrirChaveDados(dados []byte) (*Envelope, []byte, error) {
+	env := &Envelope{}
+	if err := json.Unmarshal(dados, env); err != nil || env.Versao != versao {
+		return nil, nil, ErrEnvelopeInvalido
+	}
+
+	mestra, ok := c.chaves[env.ChaveMestra]
+	if !ok {
+		return nil, nil, fmt.Errorf("chave mestra %q não está no arquivo de chaves", env.ChaveMestra)
+	}
+
+	chaveDados, err := abrir(mestra, env.ChaveDados, []byte(env.ChaveMestra))
+	if err != nil {
+		return nil, nil, ErrEnvelopeInvalido
+	}
+
+	return env, chaveDados, nil
+}
+
+// selar cifra com AES-256-GCM e retorna nonce + texto cifrado
+func selar(chave, texto, contexto []byte) ([]byte, error) {
+	aead, err := novoAEAD(chave)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(texto)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, texto, contexto), nil
+}
+
+func abrir(chave, selado, contexto []byte) ([]byte, error) {
+	aead, err := novoAEAD(chave)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selado) < aead.NonceSize() {
+		return nil, ErrEnvelopeInvalido
+	}
+
+	nonce, cifrado := selado[:aead.NonceSize()], selado[aead.NonceSize():]
+	return aead.Open(nil, nonce, cifrado, contexto)
+}
+
+func novoAEAD(chave []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(chave)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
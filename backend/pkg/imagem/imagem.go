@@ -0,0 +1,276 @@
+// Package imagem valida fotos enviadas pelos usuários e gera miniaturas
+// quadradas em JPEG. A imagem é sempre recodificada, o que descarta os
+// metadados do arquivo original (EXIF com localização, modelo do aparelho
+// etc.); apenas a orientação do EXIF é aplicada antes do descarte.
+package imagem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// Limite de pixels da imagem original, para evitar que arquivos pequenos
+// com dimensões enormes consumam memória demais ao decodificar
+const MaxPixels = 40_000_000
+
+// Qualidade das miniaturas em JPEG
+const qualidadeJPEG = 85
+
+var (
+	ErrFormatoInvalido = errors.New("arquivo não é uma imagem JPEG ou PNG válida")
+	ErrImagemGrande    = errors.New("imagem com dimensões muito grandes")
+)
+
+// Decodificar valida o conteúdo real do arquivo (não apenas a extensão ou o
+// Content-Type informado) e decodifica a imagem. Retorna também a orientação
+// EXIF (1 a 8) de fotos JPEG.
+func Decodificar(data []byte) (image.Image, int, error) {
+	tipo := http.DetectContentType(data)
+	if tipo != "image/jpeg" && tipo != "image/png" {
+		return nil, 0, ErrFormatoInvalido
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, ErrFormatoInvalido
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, 0, ErrFormatoInvalido
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, 0, ErrImagemGrande
+	}
+
+	var img image.Image
+	orientacao := 1
+	if tipo == "image/jpeg" {
+		img, err = jpeg.Decode(bytes.NewReader(data))
+		orientacao = orientacaoEXIF(data)
+	} else {
+		img, err = png.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, 0, ErrFormatoInvalido
+	}
+
+	return img, orientacao, nil
+}
+
+// Miniatura recorta o centro da imagem em um quadrado, reduz para lado×lado
+// pixels e aplica a orientação EXIF. Transparência é preenchida com branco.
+func Miniatura(img image.Image, lado, orientacao int) *image.RGBA {
+	b := img.Bounds()
+	quadrado := b.Dx()
+	if b.Dy() < quadrado {
+		quadrado = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-quadrado)/2
+	y0 := b.Min.Y + (b.Dy()-quadrado)/2
+
+	var dst *image.RGBA
+	if quadrado >= lado {
+		dst = reduzir(img, x0, y0, quadrado, lado)
+	} else {
+		dst = ampliar(img, x0, y0, quadrado, lado)
+	}
+
+	return orientar(dst, orientacao)
+}
+
+// CodificarJPEG gera o JPEG da imagem, sem metadados
+func CodificarJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: qualidadeJPEG}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// reduzir calcula a média dos pixels de origem que caem em cada pixel de
+// destino (filtro de caixa), visitando cada pixel de origem uma única vez
+func reduzir(img image.Image, x0, y0, quadrado, lado int) *image.RGBA {
+	soma := make([][4]uint64, lado*lado)
+	contagem := make([]uint64, lado*lado)
+
+	for sy := 0; sy < quadrado; sy++ {
+		dy := sy * lado / quadrado
+		for sx := 0; sx < quadrado; sx++ {
+			dx := sx * lado / quadrado
+			r, g, b, a := sobreBranco(img.At(x0+sx, y0+sy).RGBA())
+
+			i := dy*lado + dx
+			soma[i][0] += uint64(r)
+			soma[i][1] += uint64(g)
+			soma[i][2] += uint64(b)
+			soma[i][3] += uint64(a)
+			contagem[i]++
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, lado, lado))
+	for i, s := range soma {
+		n := contagem[i]
+		if n == 0 {
+			continue
+		}
+		p := i * 4
+		dst.Pix[p+0] = uint8(s[0] / n >> 8)
+		dst.Pix[p+1] = uint8(s[1] / n >> 8)
+		dst.Pix[p+2] = uint8(s[2] / n >> 8)
+		dst.Pix[p+3] = 0xff
+	}
+
+	return dst
+}
+
+// ampliar usa o vizinho mais próximo para imagens menores que a miniatura
+func ampliar(img image.Image, x0, y0, quadrado, lado int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, lado, lado))
+	for dy := 0; dy < lado; dy++ {
+		sy := dy * quadrado / lado
+		for dx := 0; dx < lado; dx++ {
+			sx := dx * quadrado / lado
+			r, g, b, _ := sobreBranco(img.At(x0+sx, y0+sy).RGBA())
+
+			p := dst.PixOffset(dx, dy)
+			dst.Pix[p+0] = uint8(r >> 8)
+			dst.Pix[p+1] = uint8(g >> 8)
+			dst.Pix[p+2] = uint8(b >> 8)
+			dst.Pix[p+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// sobreBranco compõe a cor (alfa pré-multiplicado, 16 bits) sobre fundo branco
+func sobreBranco(r, g, b, a uint32) (uint32, uint32, uint32, uint32) {
+	fundo := 0xffff - a
+	return r + fundo, g + fundo, b + fundo, 0xffff
+}
+
+// orientar aplica a orientação EXIF, levando o pixel (x, y) da imagem
+// armazenada para a posição em que deve ser exibido
+func orientar(src *image.RGBA, orientacao int) *image.RGBA {
+	if orientacao < 2 || orientacao > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientacao >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientacao {
+			case 2: // espelhada na horizontal
+				dx, dy = w-1-x, y
+			case 3: // girada 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // espelhada na vertical
+				dx, dy = x, h-1-y
+			case 5: // transposta
+				dx, dy = y, x
+			case 6: // girar 90° no sentido horário
+				dx, dy = h-1-y, x
+			case 7: // transversa
+				dx, dy = h-1-y, w-1-x
+			case 8: // girar 90° no sentido anti-horário
+				dx, dy = y, w-1-x
+			}
+
+			s := src.PixOffset(x, y)
+			d := dst.PixOffset(dx, dy)
+			copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+		}
+	}
+
+	return dst
+}
+
+// orientacaoEXIF lê a tag Orientation (0x0112) do segmento APP1 de um JPEG.
+// Retorna 1 (normal) quando não há EXIF ou ele é inválido.
+func orientacaoEXIF(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marcador := data[i+1]
+		if marcador == 0xFF { // bytes de preenchimento
+			i++
+			continue
+		}
+		if marcador == 0x01 || (marcador >= 0xD0 && marcador <= 0xD7) {
+			i += 2
+			continue
+		}
+		if marcador == 0xDA || marcador == 0xD9 { // início dos dados da imagem
+			return 1
+		}
+
+		tamanho := int(binary.BigEndian.Uint16(data[i+2:]))
+		if tamanho < 2 || i+2+tamanho > len(data) {
+			return 1
+		}
+
+		segmento := data[i+4 : i+2+tamanho]
+		if marcador == 0xE1 && len(segmento) >= 6 && string(segmento[:6]) == "Exif\x00\x00" {
+			return orientacaoTIFF(segmento[6:])
+		}
+
+		i += 2 + tamanho
+	}
+
+	return 1
+}
+
+func orientacaoTIFF(t []byte) int {
+	if len(t) < 8 {
+		return 1
+	}
+
+	var ordem binary.ByteOrder
+	switch string(t[:2]) {
+	case "II":
+		ordem = binary.LittleEndian
+	case "MM":
+		ordem = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(ordem.Uint32(t[4:]))
+	if ifd < 8 || ifd+2 > len(t) {
+		return 1
+	}
+
+	entradas := int(ordem.Uint16(t[ifd:]))
+	for k := 0; k < entradas; k++ {
+		e := ifd + 2 + k*12
+		if e+12 > len(t) {
+			return 1
+		}
+		if ordem.Uint16(t[e:]) == 0x0112 {
+			valor := int(ordem.Uint16(t[e+8:]))
+			if valor >= 1 && valor <= 8 {
+				return valor
+			}
+			return 1
+		}
+	}
+
+	return 1
+}
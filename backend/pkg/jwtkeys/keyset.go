@@ -0,0 +1,245 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key é uma chave de assinatura identificada por kid. Chaves antigas podem
+// ter apenas a parte pública, servindo somente para verificação.
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// CanSign informa se a chave possui a parte privada
+func (k *Key) CanSign() bool {
+	return k.signKey != nil
+}
+
+// KeySet reúne as chaves aceitas na verificação e a chave ativa de assinatura
+type KeySet struct {
+	keys   map[string]*Key
+	active *Key
+}
+
+// NewHMACKeySet cria um conjunto com uma única chave simétrica HS256.
+// Destinado apenas a desenvolvimento; não é publicado no JWKS.
+func NewHMACKeySet(secret string) *KeySet {
+	key := &Key{
+		ID:        "hs256",
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+	return &KeySet{keys: map[string]*Key{key.ID: key}, active: key}
+}
+
+// LoadDir carrega as chaves PEM de um diretório. O kid de cada chave é o nome
+// do arquivo sem a extensão .pem. São aceitas chaves privadas RSA (PKCS#1 ou
+// PKCS#8) e Ed25519 (PKCS#8), e chaves públicas (PKIX) para verificação de
+// tokens assinados por chaves já aposentadas.
+func LoadDir(dir, activeKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("nenhuma chave .pem encontrada em %s", dir)
+	}
+
+	ks := &KeySet{keys: map[string]*Key{}}
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		key, err := loadKey(kid, path)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys[kid] = key
+	}
+
+	if activeKID == "" {
+		// Sem kid configurado, só é possível escolher se houver uma única chave privada
+		for _, key := range ks.keys {
+			if key.CanSign() {
+				if ks.active != nil {
+					return nil, errors.New("várias chaves privadas encontradas; defina a chave ativa")
+				}
+				ks.active = key
+			}
+		}
+		if ks.active == nil {
+			return nil, errors.New("nenhuma chave privada encontrada para assinatura")
+		}
+		return ks, nil
+	}
+
+	active, ok := ks.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("chave ativa %q não encontrada", activeKID)
+	}
+	if !active.CanSign() {
+		return nil, fmt.Errorf("chave ativa %q não possui parte privada", activeKID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+func loadKey(kid, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler chave %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("arquivo %s não contém um bloco PEM", path)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("tipo de bloco PEM não suportado em %s: %s", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao interpretar chave %s: %w", path, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, verifyKey: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, verifyKey: k}, nil
+	default:
+		return nil, fmt.Errorf("algoritmo de chave não suportado em %s", path)
+	}
+}
+
+// Asymmetric informa se a chave ativa é assimétrica (RS256/EdDSA)
+func (ks *KeySet) Asymmetric() bool {
+	return ks.active.Method != jwt.SigningMethodHS256
+}
+
+// Sign assina as claims com a chave ativa, identificando-a no cabeçalho kid.
+// typ vai no cabeçalho de mesmo nome e distingue os tipos de token.
+func (ks *KeySet) Sign(claims jwt.Claims, typ string) (string, error) {
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	token.Header["kid"] = ks.active.ID
+	token.Header["typ"] = typ
+	return token.SignedString(ks.active.signKey)
+}
+
+// Parse valida a assinatura com a chave indicada pelo kid, o cabeçalho typ e
+// as claims padrão; opts acrescenta validações como iss e aud
+func (ks *KeySet) Parse(tokenString, typ string, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	opts = append(opts, jwt.WithValidMethods(ks.methods()))
+	token, err := jwt.Parse(tokenString, ks.keyfunc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if t, _ := token.Header["typ"].(string); !strings.EqualFold(t, typ) {
+		return nil, errors.New("tipo de token inválido")
+	}
+
+	return token, nil
+}
+
+func (ks *KeySet) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, errors.New("chave de assinatura desconhecida")
+	}
+
+	// Impedir confusão de algoritmo (ex.: HS256 assinado com a chave pública)
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("método de assinatura inválido")
+	}
+
+	return key.verifyKey, nil
+}
+
+func (ks *KeySet) methods() []string {
+	seen := map[string]bool{}
+	methods := []string{}
+	for _, key := range ks.keys {
+		if !seen[key.Method.Alg()] {
+			seen[key.Method.Alg()] = true
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+	return methods
+}
+
+// JWK representa uma chave pública no formato RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS representa o documento publicado em /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS retorna as chaves públicas assimétricas do conjunto
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+	return jwks
+}
@@ -0,0 +1,102 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"log"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var templates = template.Must(template.ParseFS(templatesFS, "templates/*.html"))
+
+// Message representa um email a ser enviado
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+// Mailer envia emails
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Render executa o template de email informado (ex.: "redefinicao_senha.html")
+func Render(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("erro ao renderizar template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// SMTPConfig contém as configurações do servidor SMTP
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer envia emails via SMTP. Sem usuário configurado, a autenticação é
+// omitida, o que permite usar um servidor local de testes (ex.: MailHog).
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer cria uma nova instância do mailer SMTP
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+// Send envia o email
+func (m *SMTPMailer) Send(msg Message) error {
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	addr := m.config.Host + ":" + m.config.Port
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{msg.To}, buildMessage(m.config.From, msg)); err != nil {
+		return fmt.Errorf("erro ao enviar email: %w", err)
+	}
+
+	return nil
+}
+
+// LogMailer apenas registra os emails no log; usado quando não há SMTP configurado
+type LogMailer struct{}
+
+// NewLogMailer cria uma nova instância do mailer de log
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send registra o email no log
+func (m *LogMailer) Send(msg Message) error {
+	log.Printf("Email (não enviado) para %s: %s\n%s", msg.To, msg.Subject, msg.HTML)
+	return nil
+}
+
+func buildMessage(from string, msg Message) []byte {
+	var buf strings.Builder
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + msg.To + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.HTML)
+	return []byte(buf.String())
+}
@@ -0,0 +1,425 @@
+// Package oidc implementa o lado cliente do fluxo authorization code com
+// PKCE do OpenID Connect: descoberta do provedor, URL de autorização, troca
+// do código por tokens e validação do ID token com as chaves do JWKS.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Intervalo mínimo entre duas buscas do JWKS quando aparece um kid desconhecido
+const intervaloRecargaJWKS = time.Minute
+
+// Algoritmos aceitos na assinatura do ID token
+var metodosAceitos = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+var ErrIDTokenInvalido = errors.New("ID token inválido")
+
+// Config identifica o provedor e o cliente registrado nele
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // vazio para clientes públicos
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims são os dados do ID token usados no login
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool // nil quando o provedor não informa
+	Name          string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Client fala com um provedor OIDC. A descoberta e o JWKS são buscados na
+// primeira utilização e mantidos em memória.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+
+	mu              sync.Mutex
+	discovery       *discovery
+	chaves          map[string]interface{}
+	jwksCarregadoEm time.Time
+}
+
+func New(cfg Config) *Client {
+	cfg.Issuer = strings.TrimRight(cfg.Issuer, "/")
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &Client{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewPKCE gera o code_verifier e o code_challenge (S256) de uma autorização
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// RandomString gera n bytes aleatórios codificados em base64 URL, usados em
+// state, nonce e code_verifier
+func RandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL monta a URL do provedor para onde o usuário é redirecionado
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	params := authURL.Query()
+	params.Set("response_type", "code")
+	params.Set("client_id", c.config.ClientID)
+	params.Set("redirect_uri", c.config.RedirectURL)
+	params.Set("scope", strings.Join(c.config.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+	authURL.RawQuery = params.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange troca o código de autorização pelo ID token
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	d, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.config.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	if c.config.ClientSecret == "" {
+		form.Set("client_id", c.config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.config.ClientSecret != "" {
+		// client_secret_basic (RFC 6749, seção 2.3.1)
+		req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return "", fmt.Errorf("resposta inválida do provedor (HTTP %d)", resp.StatusCode)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if tokens.Error != "" {
+			return "", fmt.Errorf("provedor recusou o código: %s %s", tokens.Error, tokens.ErrorDescription)
+		}
+		return "", fmt.Errorf("provedor recusou o código (HTTP %d)", resp.StatusCode)
+	}
+
+	if tokens.IDToken == "" {
+		return "", errors.New("provedor não retornou o ID token")
+	}
+
+	return tokens.IDToken, nil
+}
+
+// VerifyIDToken valida assinatura, emissor, audiência, validade e nonce do ID
+// token e retorna os dados do usuário
+func (c *Client) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	d, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.chave(ctx, d, kid)
+	},
+		jwt.WithValidMethods(metodosAceitos),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(c.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil || !token.Valid {
+		return nil, ErrIDTokenInvalido
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrIDTokenInvalido
+	}
+
+	if tokenNonce, _ := mapClaims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, ErrIDTokenInvalido
+	}
+
+	// Com mais de uma audiência, o token precisa ter sido emitido para este cliente
+	if aud, _ := mapClaims.GetAudience(); len(aud) > 1 {
+		if azp, _ := mapClaims["azp"].(string); azp != c.config.ClientID {
+			return nil, ErrIDTokenInvalido
+		}
+	}
+
+	claims := &Claims{}
+	claims.Subject, _ = mapClaims["sub"].(string)
+	claims.Email, _ = mapClaims["email"].(string)
+	claims.Name, _ = mapClaims["name"].(string)
+	switch verified := mapClaims["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = &verified
+	case string:
+		// Alguns provedores enviam o booleano como texto
+		v := verified == "true"
+		claims.EmailVerified = &v
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrIDTokenInvalido
+	}
+
+	return claims, nil
+}
+
+// discover busca o documento de descoberta do provedor
+func (c *Client) discover(ctx context.Context) (*discovery, error) {
+	c.mu.Lock()
+	d := c.discovery
+	c.mu.Unlock()
+	if d != nil {
+		return d, nil
+	}
+
+	d = &discovery{}
+	if err := c.getJSON(ctx, c.config.Issuer+"/.well-known/openid-configuration", d); err != nil {
+		return nil, fmt.Errorf("erro na descoberta do provedor OIDC: %w", err)
+	}
+
+	if strings.TrimRight(d.Issuer, "/") != c.config.Issuer {
+		return nil, fmt.Errorf("issuer do provedor (%s) difere do configurado (%s)", d.Issuer, c.config.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, errors.New("documento de descoberta do provedor OIDC incompleto")
+	}
+
+	c.mu.Lock()
+	c.discovery = d
+	c.mu.Unlock()
+
+	return d, nil
+}
+
+// chave retorna a chave pública do kid, buscando o JWKS novamente quando o
+// kid não é conhecido (rotação de chaves no provedor)
+func (c *Client) chave(ctx context.Context, d *discovery, kid string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chave, ok := c.buscarChave(kid); ok {
+		return chave, nil
+	}
+
+	if time.Since(c.jwksCarregadoEm) < intervaloRecargaJWKS {
+		return nil, errors.New("chave do ID token desconhecida")
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := c.getJSON(ctx, d.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+
+	chaves := map[string]interface{}{}
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		chave, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		chaves[k.Kid] = chave
+	}
+
+	c.chaves = chaves
+	c.jwksCarregadoEm = time.Now()
+
+	if chave, ok := c.buscarChave(kid); ok {
+		return chave, nil
+	}
+	return nil, errors.New("chave do ID token desconhecida")
+}
+
+// buscarChave procura a chave pelo kid; sem kid, aceita a única chave publicada
+func (c *Client) buscarChave(kid string) (interface{}, bool) {
+	if kid == "" && len(c.chaves) == 1 {
+		for _, chave := range c.chaves {
+			return chave, true
+		}
+	}
+	chave, ok := c.chaves[kid]
+	return chave, ok
+}
+
+func (c *Client) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: HTTP %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("expoente RSA inválido")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva não suportada: %s", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("ponto EC inválido")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("curva não suportada: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("chave Ed25519 inválida")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("tipo de chave não suportado: %s", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("valor inválido na chave JWK")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
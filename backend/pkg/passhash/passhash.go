@@ -0,0 +1,172 @@
+// Package passhash gera e verifica hashes de senha versionados. O hash
+// carrega o algoritmo e os parâmetros usados (formato PHC para argon2id e o
+// formato padrão do bcrypt), o que permite verificar hashes antigos e
+// identificar quais precisam ser refeitos com os parâmetros atuais.
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algoritmos suportados
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+const (
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// Params define o algoritmo usado para novos hashes e seus parâmetros
+type Params struct {
+	Algoritmo string
+
+	Argon2Memory  uint32 // em KiB
+	Argon2Time    uint32 // iterações
+	Argon2Threads uint8
+
+	BcryptCost int
+}
+
+// Hasher gera hashes com os parâmetros atuais e verifica hashes de qualquer
+// algoritmo suportado
+type Hasher struct {
+	params Params
+}
+
+func New(params Params) (*Hasher, error) {
+	switch params.Algoritmo {
+	case Argon2id:
+		if params.Argon2Memory == 0 || params.Argon2Time == 0 || params.Argon2Threads == 0 {
+			return nil, errors.New("parâmetros do argon2id devem ser positivos")
+		}
+	case Bcrypt:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("custo do bcrypt deve estar entre %d e %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("algoritmo de senha não suportado: %s", params.Algoritmo)
+	}
+
+	return &Hasher{params: params}, nil
+}
+
+// Hash gera o hash da senha com o algoritmo e os parâmetros atuais
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.params.Algoritmo == Bcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Argon2Time, h.params.Argon2Memory, h.params.Argon2Threads, argon2KeyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.Argon2Memory,
+		h.params.Argon2Time,
+		h.params.Argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify compara a senha com um hash em qualquer formato suportado
+func (h *Hasher) Verify(encoded, password string) bool {
+	if isBcrypt(encoded) {
+		return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+	}
+
+	hash, err := parseArgon2id(encoded)
+	if err != nil {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(password), hash.salt, hash.time, hash.memory, hash.threads, uint32(len(hash.key)))
+	return subtle.ConstantTimeCompare(key, hash.key) == 1
+}
+
+// NeedsRehash informa se o hash foi gerado com outro algoritmo ou com
+// parâmetros diferentes dos atuais
+func (h *Hasher) NeedsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		if h.params.Algoritmo != Bcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.params.BcryptCost
+	}
+
+	hash, err := parseArgon2id(encoded)
+	if err != nil || h.params.Algoritmo != Argon2id {
+		return true
+	}
+
+	return hash.memory != h.params.Argon2Memory ||
+		hash.time != h.params.Argon2Time ||
+		hash.threads != h.params.Argon2Threads ||
+		len(hash.key) != argon2KeyLen
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+type argon2Hash struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// parseArgon2id lê um hash no formato $argon2id$v=19$m=...,t=...,p=...$salt$key
+func parseArgon2id(encoded string) (*argon2Hash, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return nil, errors.New("hash argon2id inválido")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, errors.New("versão do argon2id não suportada")
+	}
+
+	hash := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &hash.memory, &hash.time, &hash.threads); err != nil {
+		return nil, errors.New("parâmetros do argon2id inválidos")
+	}
+
+	var err error
+	if hash.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, err
+	}
+	if hash.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, err
+	}
+	if len(hash.key) == 0 {
+		return nil, errors.New("hash argon2id inválido")
+	}
+
+	return hash, nil
+}
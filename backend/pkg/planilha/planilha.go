@@ -0,0 +1,322 @@
+// Package planilha lê planilhas enviadas pelos usuários, em CSV ou XLSX, como
+// linhas de texto. Do XLSX é lida apenas a primeira aba, sem fórmulas: vale o
+// último valor calculado salvo no arquivo.
+package planilha
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limite de cada XML descompactado do XLSX, para que arquivos pequenos não
+// ocupem memória demais ao serem descompactados
+const maxXMLDescompactado = 32 << 20
+
+var ErrFormatoInvalido = errors.New("arquivo não é uma planilha CSV ou XLSX válida")
+
+// Linha é uma linha da planilha com o número que ela tem no arquivo (a
+// partir de 1), para que os erros apontem a linha que o usuário vê
+type Linha struct {
+	Numero  int
+	Celulas []string
+}
+
+// Ler identifica o formato pelo conteúdo e retorna as linhas da planilha.
+// Linhas totalmente vazias são descartadas, sem alterar a numeração das demais.
+func Ler(data []byte) ([]Linha, error) {
+	var linhas []Linha
+	var err error
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		linhas, err = lerXLSX(data)
+	} else {
+		linhas, err = lerCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	preenchidas := linhas[:0]
+	for _, linha := range linhas {
+		for _, celula := range linha.Celulas {
+			if strings.TrimSpace(celula) != "" {
+				preenchidas = append(preenchidas, linha)
+				break
+			}
+		}
+	}
+
+	return preenchidas, nil
+}
+
+// DataExcel converte o número de série usado pelo Excel para datas (dias
+// desde 30/12/1899) em data
+func DataExcel(serie float64) time.Time {
+	dias := math.Floor(serie)
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(dias))
+}
+
+// lerCSV aceita vírgula ou ponto e vírgula como separador (o Excel em
+// português exporta com ponto e vírgula) e arquivos em UTF-8 ou Latin-1
+func lerCSV(data []byte) ([]Linha, error) {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return nil, ErrFormatoInvalido
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	if !utf8.Valid(data) {
+		data = latin1ParaUTF8(data)
+	}
+
+	primeira := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		primeira = data[:i]
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if bytes.Count(primeira, []byte(";")) > bytes.Count(primeira, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	linhas := []Linha{}
+	for {
+		celulas, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrFormatoInvalido
+		}
+
+		// Linha em que o registro começa; campos entre aspas podem ocupar várias
+		numero, _ := reader.FieldPos(0)
+		linhas = append(linhas, Linha{Numero: numero, Celulas: celulas})
+	}
+
+	return linhas, nil
+}
+
+func latin1ParaUTF8(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data) * 2)
+	for _, b := range data {
+		buf.WriteRune(rune(b))
+	}
+	return buf.Bytes()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxTexto `xml:"si"`
+}
+
+// xlsxTexto é um texto simples (<t>) ou formatado em trechos (<r><t>)
+type xlsxTexto struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxTexto) String() string {
+	if len(t.R) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.R {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Numero string `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string    `xml:"r,attr"`
+			Tipo   string    `xml:"t,attr"`
+			Valor  string    `xml:"v"`
+			Inline xlsxTexto `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func lerXLSX(data []byte) ([]Linha, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrFormatoInvalido
+	}
+
+	arquivos := map[string]*zip.File{}
+	for _, f := range zr.File {
+		arquivos[f.Name] = f
+	}
+
+	caminho, err := primeiraAba(arquivos)
+	if err != nil {
+		return nil, err
+	}
+
+	var compartilhados xlsxSharedStrings
+	if f, ok := arquivos["xl/sharedStrings.xml"]; ok {
+		if err := lerXML(f, &compartilhados); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := arquivos[caminho]
+	if !ok {
+		return nil, ErrFormatoInvalido
+	}
+	var aba xlsxWorksheet
+	if err := lerXML(f, &aba); err != nil {
+		return nil, err
+	}
+
+	linhas := make([]Linha, 0, len(aba.Rows))
+	numero := 0
+	for _, row := range aba.Rows {
+		// Linhas vazias podem não estar no XML; sem o atributo r, a linha é a
+		// seguinte à anterior
+		if row.Numero != "" {
+			n, err := strconv.Atoi(row.Numero)
+			if err != nil || n <= numero {
+				return nil, ErrFormatoInvalido
+			}
+			numero = n
+		} else {
+			numero++
+		}
+
+		linha := []string{}
+		for _, c := range row.Cells {
+			coluna := len(linha)
+			if c.Ref != "" {
+				if coluna = indiceColuna(c.Ref); coluna < 0 {
+					return nil, ErrFormatoInvalido
+				}
+			}
+			for len(linha) <= coluna {
+				linha = append(linha, "")
+			}
+
+			switch c.Tipo {
+			case "s":
+				i, err := strconv.Atoi(c.Valor)
+				if err != nil || i < 0 || i >= len(compartilhados.Items) {
+					return nil, ErrFormatoInvalido
+				}
+				linha[coluna] = compartilhados.Items[i].String()
+			case "inlineStr":
+				linha[coluna] = c.Inline.String()
+			case "", "n":
+				// Números grandes (telefones, CPFs) podem vir em notação científica
+				if n, err := strconv.ParseFloat(c.Valor, 64); err == nil {
+					linha[coluna] = strconv.FormatFloat(n, 'f', -1, 64)
+				} else {
+					linha[coluna] = c.Valor
+				}
+			default:
+				linha[coluna] = c.Valor
+			}
+		}
+		linhas = append(linhas, Linha{Numero: numero, Celulas: linha})
+	}
+
+	return linhas, nil
+}
+
+// primeiraAba encontra o XML da primeira aba pelo workbook e seus
+// relacionamentos, que indicam o arquivo de cada aba
+func primeiraAba(arquivos map[string]*zip.File) (string, error) {
+	const padrao = "xl/worksheets/sheet1.xml"
+
+	fw, okW := arquivos["xl/workbook.xml"]
+	fr, okR := arquivos["xl/_rels/workbook.xml.rels"]
+	if !okW || !okR {
+		return padrao, nil
+	}
+
+	var workbook xlsxWorkbook
+	if err := lerXML(fw, &workbook); err != nil {
+		return "", err
+	}
+	var rels xlsxRelationships
+	if err := lerXML(fr, &rels); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", ErrFormatoInvalido
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return padrao, nil
+}
+
+func lerXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return ErrFormatoInvalido
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxXMLDescompactado+1))
+	if err != nil || len(data) > maxXMLDescompactado {
+		return ErrFormatoInvalido
+	}
+
+	if err := xml.Unmarshal(data, v); err != nil {
+		return ErrFormatoInvalido
+	}
+	return nil
+}
+
+// indiceColuna converte a referência da célula (ex.: "C12") no índice da
+// coluna, a partir de zero
+func indiceColuna(ref string) int {
+	coluna := 0
+	letras := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		coluna = coluna*26 + int(r-'A'+1)
+		letras++
+	}
+	if letras == 0 || letras > 3 {
+		return -1
+	}
+	return coluna - 1
+}
@@ -57,10 +57,11 @@ export const AuthProvider: React.FC<{children: React.ReactNode}> = ({ children }
   async function signIn(email: string, senha: string) {
     try {
       const response = await api.post('/auth/login', { email, senha });
-      const { token, user } = response.data;
+      const { token, refresh_token, user } = response.data;
 
       await Promise.all([
         AsyncStorage.setItem('@RLSApp:token', token),
+        AsyncStorage.setItem('@RLSApp:refreshToken', refresh_token),
         AsyncStorage.setItem('@RLSApp:user', JSON.stringify(user))
       ]);
 
@@ -75,8 +76,13 @@ export const AuthProvider: React.FC<{children: React.ReactNode}> = ({ children }
   // Logout
   async function signOut() {
     try {
+      // Revogar tokens no servidor (melhor esforço)
+      const refreshToken = await AsyncStorage.getItem('@RLSApp:refreshToken');
+      await api.post('/auth/logout', { refresh_token: refreshToken }).catch(() => undefined);
+
       await Promise.all([
         AsyncStorage.removeItem('@RLSApp:token'),
+        AsyncStorage.removeItem('@RLSApp:refreshToken'),
         AsyncStorage.removeItem('@RLSApp:user')
       ]);
 
@@ -57,7 +57,7 @@ export default function DetalhesDocumentoScreen() {
       setShowApproveReject(false);
       carregarDocumento();
     } catch (error) {
-      Alert.alert('Erro', 'Não foi possível aprovar o documento');
+      Alert.alert('Erro', error.response?.data?.error || 'Não foi possível aprovar o documento');
     } finally {
       setApproveLoading(false);
     }
@@ -76,7 +76,7 @@ export default function DetalhesDocumentoScreen() {
       setShowApproveReject(false);
       carregarDocumento();
     } catch (error) {
-      Alert.alert('Erro', 'Não foi possível rejeitar o documento');
+      Alert.alert('Erro', error.response?.data?.error || 'Não foi possível rejeitar o documento');
     } finally {
       setApproveLoading(false);
     }
@@ -205,7 +205,7 @@ export default function DetalhesDocumentoScreen() {
         )}
       </View>
 
-      {isAdmin && documento.status === 'pendente' && (
+      {(isAdmin || documento.aprovador_id === user?.id) && documento.status === 'pendente' && (
         <View style={styles.adminContainer}>
           {!showApproveReject ? (
             <TouchableOpacity
@@ -22,14 +22,57 @@ api.interceptors.request.use(
   (error) => Promise.reject(error)
 );
 
+// Renovação do token de acesso (compartilhada entre requisições simultâneas)
+let refreshPromise: Promise<string | null> | null = null;
+
+async function renovarToken(): Promise<string | null> {
+  const refreshToken = await AsyncStorage.getItem('@RLSApp:refreshToken');
+  if (!refreshToken) {
+    return null;
+  }
+
+  try {
+    const response = await axios.post(`${api.defaults.baseURL}/auth/refresh`, {
+      refresh_token: refreshToken,
+    });
+    const { token, refresh_token } = response.data;
+
+    await Promise.all([
+      AsyncStorage.setItem('@RLSApp:token', token),
+      AsyncStorage.setItem('@RLSApp:refreshToken', refresh_token),
+    ]);
+    api.defaults.headers.common['Authorization'] = `Bearer ${token}`;
+
+    return token;
+  } catch {
+    return null;
+  }
+}
+
 // Interceptor para tratamento de erros
 api.interceptors.response.use(
   (response) => response,
   async (error) => {
-    if (error.response?.status === 401) {
-      // Token expirado ou inválido
-      await AsyncStorage.removeItem('@RLSApp:token');
-      await AsyncStorage.removeItem('@RLSApp:user');
+    const originalRequest = error.config;
+
+    if (error.response?.status === 401 && originalRequest && !originalRequest._retry) {
+      // Token expirado: tentar renovar uma única vez
+      originalRequest._retry = true;
+
+      if (!refreshPromise) {
+        refreshPromise = renovarToken().finally(() => {
+          refreshPromise = null;
+        });
+      }
+
+      const token = await refreshPromise;
+      if (token) {
+        originalRequest.headers.Authorization = `Bearer ${token}`;
+        return api(originalRequest);
+      }
+
+      // Refresh token inválido ou expirado
+      await AsyncStorage.multiRemove(['@RLSApp:token', '@RLSApp:refreshToken', '@RLSApp:user']);
     }
     return Promise.reject(error);
   }